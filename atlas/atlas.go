@@ -0,0 +1,262 @@
+// Package atlas packs images into texture atlases, so that many sprites
+// can be drawn from a single texture.
+//
+// Atlases can be built when a game loads with render.LoadAtlas, or ahead
+// of time with the gologo atlas command, which writes the atlas images
+// and a JSON description of the regions for render.LoadAtlasFile.
+package atlas
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"os"
+	"sort"
+
+	// Bring in png so we support this file format
+	_ "image/png"
+)
+
+// Options : MaxSize is the largest width and height of an atlas page,
+// 2048 if zero.  Padding is the number of transparent pixels between
+// images and around the edge of each page.  Extrude is the number of
+// times the edge pixels of each image are repeated outwards, so that
+// filtering at the edge of a region does not sample its neighbours.
+type Options struct {
+	MaxSize int
+	Padding int
+	Extrude int
+}
+
+// Page : An atlas image and the region of it holding each packed image,
+// by name.  Names lists the regions in the order the images were given.
+type Page struct {
+	Image   *image.RGBA
+	Regions map[string]image.Rectangle
+	Names   []string
+}
+
+// Placement : The page an image was packed into and its region there
+type Placement struct {
+	Page   int
+	Region image.Rectangle
+}
+
+const (
+	defaultMaxSize = 2048
+	minPageSize    = 64
+)
+
+// Pack : Returns the placement of rectangles of the sizes on as few pages
+// as possible, and the size of each page.  Page sizes are powers of two
+// no larger than options.MaxSize, which must itself be a power of two.
+// Rectangles are placed along shelves, tallest first.
+func Pack(sizes []image.Point, options Options) ([]Placement, []image.Point, error) {
+	maxSize := options.MaxSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxSize
+	}
+	if maxSize&(maxSize-1) != 0 {
+		return nil, nil, fmt.Errorf("atlas size %v is not a power of two", maxSize)
+	}
+	if options.Padding < 0 || options.Extrude < 0 {
+		return nil, nil, fmt.Errorf("atlas padding %v and extrude %v must not be negative", options.Padding, options.Extrude)
+	}
+
+	cells := make([]image.Point, len(sizes))
+	for i, size := range sizes {
+		cells[i] = size.Add(image.Pt(2*options.Extrude+options.Padding, 2*options.Extrude+options.Padding))
+		if cells[i].X+options.Padding > maxSize || cells[i].Y+options.Padding > maxSize {
+			return nil, nil, fmt.Errorf("image %v of size %v does not fit in atlas of size %v", i, size, maxSize)
+		}
+	}
+
+	order := make([]int, len(sizes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		if cells[order[a]].Y != cells[order[b]].Y {
+			return cells[order[a]].Y > cells[order[b]].Y
+		}
+		return cells[order[a]].X > cells[order[b]].X
+	})
+
+	placements := make([]Placement, len(sizes))
+	pages := []image.Point{}
+	for len(order) > 0 {
+		size := startingSize(cells, order, options.Padding, maxSize)
+		for {
+			placed, rest := packShelves(cells, order, size, options.Padding)
+			if len(rest) == 0 || (size.X >= maxSize && size.Y >= maxSize) {
+				for i, origin := range placed {
+					offset := origin.Add(image.Pt(options.Extrude, options.Extrude))
+					placements[i] = Placement{
+						Page:   len(pages),
+						Region: image.Rectangle{Min: offset, Max: offset.Add(sizes[i])},
+					}
+				}
+				pages = append(pages, size)
+				order = rest
+				break
+			}
+
+			if size.X <= size.Y && size.X < maxSize {
+				size.X *= 2
+			} else {
+				size.Y *= 2
+			}
+		}
+	}
+
+	return placements, pages, nil
+}
+
+// startingSize : Returns the smallest square power of two page with room
+// for the area of the cells, which is where the search for a page size
+// which fits them all starts
+func startingSize(cells []image.Point, order []int, padding int, maxSize int) image.Point {
+	area := 0
+	for _, i := range order {
+		area += cells[i].X * cells[i].Y
+	}
+
+	side := minPageSize
+	for side < maxSize && (side-padding)*(side-padding) < area {
+		side *= 2
+	}
+	if side > maxSize {
+		side = maxSize
+	}
+	return image.Pt(side, side)
+}
+
+// packShelves : Places the cells in order along shelves in a page of the
+// size, returning the top left of each placed cell by index and the
+// indexes of the cells which did not fit
+func packShelves(cells []image.Point, order []int, size image.Point, padding int) (map[int]image.Point, []int) {
+	placed := map[int]image.Point{}
+	rest := []int{}
+
+	x, y, shelfHeight := padding, padding, 0
+	for _, i := range order {
+		cell := cells[i]
+		if x+cell.X > size.X {
+			x = padding
+			y += shelfHeight
+			shelfHeight = 0
+		}
+		if x+cell.X > size.X || y+cell.Y > size.Y {
+			rest = append(rest, i)
+			continue
+		}
+
+		placed[i] = image.Pt(x, y)
+		x += cell.X
+		if cell.Y > shelfHeight {
+			shelfHeight = cell.Y
+		}
+	}
+
+	return placed, rest
+}
+
+// Build : Packs the images into atlas pages, naming the region of each
+// image by the name at the same index
+func Build(names []string, images []image.Image, options Options) ([]*Page, error) {
+	if len(names) != len(images) {
+		return nil, fmt.Errorf("%v names for %v images", len(names), len(images))
+	}
+
+	seen := map[string]bool{}
+	sizes := make([]image.Point, len(images))
+	for i, img := range images {
+		if seen[names[i]] {
+			return nil, fmt.Errorf("duplicate image name: %q", names[i])
+		}
+		seen[names[i]] = true
+		sizes[i] = img.Bounds().Size()
+	}
+
+	placements, pageSizes, err := Pack(sizes, options)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := make([]*Page, len(pageSizes))
+	for i, size := range pageSizes {
+		pages[i] = &Page{
+			Image:   image.NewRGBA(image.Rectangle{Max: size}),
+			Regions: map[string]image.Rectangle{},
+		}
+	}
+
+	for i, placement := range placements {
+		page := pages[placement.Page]
+		draw.Draw(page.Image, placement.Region, images[i], images[i].Bounds().Min, draw.Src)
+		extrude(page.Image, placement.Region, options.Extrude)
+		page.Regions[names[i]] = placement.Region
+		page.Names = append(page.Names, names[i])
+	}
+
+	return pages, nil
+}
+
+// Load : Packs the image files into atlas pages, naming each region by
+// the path of its file
+func Load(paths []string, options Options) ([]*Page, error) {
+	images := make([]image.Image, len(paths))
+	for i, path := range paths {
+		img, err := loadImage(path)
+		if err != nil {
+			return nil, err
+		}
+		images[i] = img
+	}
+
+	return Build(paths, images, options)
+}
+
+// extrude : Repeats the edge pixels of the region outwards count times,
+// filling the corners with the corner pixels
+func extrude(rgba *image.RGBA, region image.Rectangle, count int) {
+	if count <= 0 || region.Empty() {
+		return
+	}
+
+	outer := region.Inset(-count).Intersect(rgba.Rect)
+	for y := outer.Min.Y; y < outer.Max.Y; y++ {
+		for x := outer.Min.X; x < outer.Max.X; x++ {
+			if image.Pt(x, y).In(region) {
+				continue
+			}
+			rgba.SetRGBA(x, y, rgba.RGBAAt(
+				clamp(x, region.Min.X, region.Max.X-1),
+				clamp(y, region.Min.Y, region.Max.Y-1)))
+		}
+	}
+}
+
+func clamp(value int, min int, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+func loadImage(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load image %q: %v", path, err)
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image %q: %v", path, err)
+	}
+	return img, nil
+}
@@ -0,0 +1,151 @@
+package atlas
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var packTests = []struct {
+	name    string
+	sizes   []image.Point
+	options Options
+	pages   int
+}{
+	{"single", []image.Point{{10, 20}}, Options{}, 1},
+	{"mixed", []image.Point{{30, 10}, {10, 40}, {64, 64}, {5, 5}, {20, 20}}, Options{Padding: 2, Extrude: 1}, 1},
+	{"overflow", []image.Point{{40, 40}, {40, 40}, {40, 40}, {40, 40}, {40, 40}}, Options{MaxSize: 64, Padding: 1}, 5},
+	{"many small", manySizes(100, image.Pt(12, 8)), Options{MaxSize: 64}, 3},
+}
+
+func manySizes(count int, size image.Point) []image.Point {
+	sizes := make([]image.Point, count)
+	for i := range sizes {
+		sizes[i] = size
+	}
+	return sizes
+}
+
+// TestPack : Test that packed rectangles keep their size, stay within
+// their page and are separated by the padding
+func TestPack(t *testing.T) {
+	for _, tc := range packTests {
+		t.Run(tc.name, func(t *testing.T) {
+			placements, pages, err := Pack(tc.sizes, tc.options)
+			if err != nil {
+				t.Fatalf("Pack failed: %v", err)
+			}
+			if len(pages) != tc.pages {
+				t.Errorf("Pages were (%v) should be (%v)", len(pages), tc.pages)
+			}
+
+			gap := tc.options.Padding + 2*tc.options.Extrude
+			for i, placement := range placements {
+				if placement.Region.Size() != tc.sizes[i] {
+					t.Errorf("Size of (%v) was (%v) should be (%v)", i, placement.Region.Size(), tc.sizes[i])
+				}
+				page := image.Rectangle{Max: pages[placement.Page]}.Inset(tc.options.Padding + tc.options.Extrude)
+				if !placement.Region.In(page) {
+					t.Errorf("Region of (%v) was (%v) should be inside (%v)", i, placement.Region, page)
+				}
+
+				for j := 0; j < i; j++ {
+					other := placements[j]
+					if other.Page == placement.Page && other.Region.Inset(-gap).Overlaps(placement.Region) {
+						t.Errorf("Regions (%v) and (%v) should be (%v) apart", placement.Region, other.Region, gap)
+					}
+				}
+			}
+		})
+	}
+
+	for _, options := range []Options{
+		{MaxSize: 64},
+		{MaxSize: 1000},
+		{Padding: -1},
+		{Extrude: -1},
+	} {
+		if _, _, err := Pack([]image.Point{{100, 10}}, options); err == nil {
+			t.Errorf("Pack with options (%+v) should fail", options)
+		}
+	}
+}
+
+// TestExtrude : Test that the edge pixels of each image are repeated into
+// the space around it
+func TestExtrude(t *testing.T) {
+	red := solidImage(4, 4, color.RGBA{255, 0, 0, 255})
+	green := solidImage(4, 4, color.RGBA{0, 255, 0, 255})
+
+	pages, err := Build([]string{"red", "green"}, []image.Image{red, green}, Options{Padding: 1, Extrude: 2})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	page := pages[0]
+	region := page.Regions["red"]
+
+	for _, p := range []image.Point{
+		region.Min.Sub(image.Pt(2, 2)),
+		image.Pt(region.Max.X+1, region.Min.Y),
+		image.Pt(region.Min.X, region.Max.Y+1),
+	} {
+		if c := page.Image.RGBAAt(p.X, p.Y); c != (color.RGBA{255, 0, 0, 255}) {
+			t.Errorf("Pixel at (%v) was (%v) should be red", p, c)
+		}
+	}
+	if c := page.Image.RGBAAt(region.Min.X-3, region.Min.Y); c.A != 0 {
+		t.Errorf("Padding pixel was (%v) should be transparent", c)
+	}
+}
+
+// TestWriteReadFile : Test that written atlases are read back with region
+// names relative to the JSON file
+func TestWriteReadFile(t *testing.T) {
+	dir := t.TempDir()
+	names := []string{filepath.Join(dir, "sprites", "a.png"), filepath.Join(dir, "b.png")}
+	images := []image.Image{
+		solidImage(40, 40, color.RGBA{255, 0, 0, 255}),
+		solidImage(40, 40, color.RGBA{0, 0, 255, 255}),
+	}
+
+	pages, err := Build(names, images, Options{MaxSize: 64})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	path := filepath.Join(dir, "out", "atlas.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := Write(pages, path); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	file, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if len(file.Pages) != 2 || file.Pages[0].Image != "atlas.png" || file.Pages[1].Image != "atlas-1.png" {
+		t.Fatalf("Pages were (%v) should be atlas.png and atlas-1.png", file.Pages)
+	}
+
+	region := file.Pages[0].Regions[0]
+	if region.Name != "../sprites/a.png" || region.Rect() != pages[0].Regions[names[0]] {
+		t.Errorf("Region was (%v) should be (%v) named (%v)", region, pages[0].Regions[names[0]], "../sprites/a.png")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "out", "atlas-1.png")); err != nil {
+		t.Errorf("Second atlas image should be written: %v", err)
+	}
+}
+
+func solidImage(width int, height int, c color.RGBA) *image.RGBA {
+	rgba := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			rgba.SetRGBA(x, y, c)
+		}
+	}
+	return rgba
+}
@@ -0,0 +1,133 @@
+package atlas
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// File : The JSON description of atlas pages written by Write.  Image
+// paths are relative to the JSON file.
+//
+//	{
+//	  "pages": [{
+//	    "image": "atlas.png",
+//	    "w": 256, "h": 128,
+//	    "regions": [{"name": "sprites/hero.png", "x": 1, "y": 1, "w": 32, "h": 32}]
+//	  }]
+//	}
+type File struct {
+	Pages []FilePage `json:"pages"`
+}
+
+// FilePage : One atlas image and its regions
+type FilePage struct {
+	Image   string       `json:"image"`
+	W       int          `json:"w"`
+	H       int          `json:"h"`
+	Regions []FileRegion `json:"regions"`
+}
+
+// FileRegion : The name and bounds of a packed image
+type FileRegion struct {
+	Name string `json:"name"`
+	X    int    `json:"x"`
+	Y    int    `json:"y"`
+	W    int    `json:"w"`
+	H    int    `json:"h"`
+}
+
+// Rect : Returns the bounds of the region
+func (r FileRegion) Rect() image.Rectangle {
+	return image.Rect(r.X, r.Y, r.X+r.W, r.Y+r.H)
+}
+
+// Write : Writes the pages as PNG images next to the JSON file at path,
+// named after it with a page number for every page after the first.  Region
+// names which are file paths are written relative to the JSON file, so
+// that they resolve to the same files when it is read by ReadFile.
+func Write(pages []*Page, path string) error {
+	dir := filepath.Dir(path)
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	file := File{Pages: make([]FilePage, len(pages))}
+	for i, page := range pages {
+		imageName := base + ".png"
+		if i > 0 {
+			imageName = fmt.Sprintf("%v-%v.png", base, i)
+		}
+		if err := writePNG(filepath.Join(dir, imageName), page.Image); err != nil {
+			return err
+		}
+
+		size := page.Image.Rect.Size()
+		filePage := FilePage{Image: imageName, W: size.X, H: size.Y}
+		for _, name := range page.Names {
+			region := page.Regions[name]
+			filePage.Regions = append(filePage.Regions, FileRegion{
+				Name: relativeName(dir, name),
+				X:    region.Min.X,
+				Y:    region.Min.Y,
+				W:    region.Dx(),
+				H:    region.Dy(),
+			})
+		}
+		file.Pages[i] = filePage
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// ReadFile : Reads the JSON description of atlas pages at path.  Image
+// paths and region names are returned relative to the JSON file.
+func ReadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file File
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse atlas %q: %v", path, err)
+	}
+	return &file, nil
+}
+
+// relativeName : Returns the region name relative to dir, using forward
+// slashes, if it is a file path that can be made relative
+func relativeName(dir string, name string) string {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return filepath.ToSlash(name)
+	}
+	absName, err := filepath.Abs(name)
+	if err != nil {
+		return filepath.ToSlash(name)
+	}
+	relative, err := filepath.Rel(absDir, absName)
+	if err != nil {
+		return filepath.ToSlash(name)
+	}
+	return filepath.ToSlash(relative)
+}
+
+func writePNG(path string, rgba *image.RGBA) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(file, rgba); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,158 @@
+// Command gologo provides tools for preparing gologo game assets.
+//
+// Usage:
+//
+//	gologo atlas [-o atlas.json] [-max 2048] [-padding 2] [-extrude 1] image|dir...
+//
+// The atlas command packs PNG images, and the PNG images in directories,
+// into atlas images written next to the JSON file given by -o, which
+// describes where each image was packed.  Load the result with
+// render.LoadAtlasFile.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/leedenison/gologo/atlas"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run : Runs the command named by the first argument and returns the exit
+// status
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+
+	switch args[0] {
+	case "atlas":
+		return runAtlas(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		usage(stdout)
+		return 0
+	}
+
+	fmt.Fprintf(stderr, "gologo: unknown command %q\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w, "  gologo atlas [-o atlas.json] [-max 2048] [-padding 2] [-extrude 1] image|dir...")
+}
+
+// runAtlas : Packs the images named by args into an atlas
+func runAtlas(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("atlas", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	output := flags.String("o", "atlas.json", "path of the JSON description; images are written next to it")
+	maxSize := flags.Int("max", 2048, "largest width and height of an atlas image")
+	padding := flags.Int("padding", 2, "transparent pixels between images")
+	extrude := flags.Int("extrude", 1, "times the edge pixels of each image are repeated outwards")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	paths, err := imagePaths(flags.Args(), *output)
+	if err != nil {
+		fmt.Fprintf(stderr, "gologo atlas: %v\n", err)
+		return 1
+	}
+	if len(paths) == 0 {
+		fmt.Fprintln(stderr, "gologo atlas: no images to pack")
+		return 2
+	}
+
+	pages, err := atlas.Load(paths, atlas.Options{
+		MaxSize: *maxSize,
+		Padding: *padding,
+		Extrude: *extrude,
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "gologo atlas: %v\n", err)
+		return 1
+	}
+
+	if err := os.MkdirAll(filepath.Dir(*output), 0755); err != nil {
+		fmt.Fprintf(stderr, "gologo atlas: %v\n", err)
+		return 1
+	}
+	if err := atlas.Write(pages, *output); err != nil {
+		fmt.Fprintf(stderr, "gologo atlas: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(stdout, "Packed %v images into %v atlas images described by %v\n", len(paths), len(pages), *output)
+	return 0
+}
+
+// imagePaths : Returns the paths, with each directory replaced by the PNG
+// images inside it in name order.  Atlas images previously written for
+// the output are skipped, so that an atlas can be written into the
+// directory it is packed from.
+func imagePaths(args []string, output string) ([]string, error) {
+	paths := []string{}
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+
+		found := []string{}
+		err = filepath.Walk(arg, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && strings.EqualFold(filepath.Ext(path), ".png") && !isAtlasImage(path, output) {
+				found = append(found, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(found)
+		paths = append(paths, found...)
+	}
+	return paths, nil
+}
+
+// isAtlasImage : Returns true if path is one of the atlas images written
+// by atlas.Write for the JSON file output
+func isAtlasImage(path string, output string) bool {
+	if filepath.Clean(filepath.Dir(path)) != filepath.Clean(filepath.Dir(output)) {
+		return false
+	}
+
+	base := strings.TrimSuffix(filepath.Base(output), filepath.Ext(output))
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if name == base {
+		return true
+	}
+
+	page := strings.TrimPrefix(name, base+"-")
+	if page == name || page == "" {
+		return false
+	}
+	for _, c := range page {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leedenison/gologo/atlas"
+)
+
+// TestRunAtlas : Test that the atlas command packs the images in a
+// directory, and skips its own output when run again
+func TestRunAtlas(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.png", "a.png", "notes.txt"} {
+		writeFile(t, filepath.Join(dir, name), image.Rect(0, 0, 10, 10))
+	}
+	output := filepath.Join(dir, "atlas.json")
+
+	for i := 0; i < 2; i++ {
+		var stdout, stderr bytes.Buffer
+		if status := run([]string{"atlas", "-o", output, "-max", "64", dir}, &stdout, &stderr); status != 0 {
+			t.Fatalf("Status was (%v) should be (0): %v", status, stderr.String())
+		}
+
+		file, err := atlas.ReadFile(output)
+		if err != nil {
+			t.Fatalf("ReadFile failed: %v", err)
+		}
+		if len(file.Pages) != 1 || len(file.Pages[0].Regions) != 2 {
+			t.Fatalf("Atlas was (%v) should be one page of two regions", file.Pages)
+		}
+		if name := file.Pages[0].Regions[0].Name; name != "a.png" {
+			t.Errorf("First region was (%v) should be (%v)", name, "a.png")
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "atlas.png")); err != nil {
+		t.Errorf("Atlas image should be written: %v", err)
+	}
+}
+
+var runErrorTests = []struct {
+	name     string
+	args     []string
+	expected int
+}{
+	{"no command", []string{}, 2},
+	{"unknown command", []string{"pack"}, 2},
+	{"no images", []string{"atlas"}, 2},
+	{"missing image", []string{"atlas", "missing.png"}, 1},
+}
+
+// TestRunErrors : Test the exit status of invalid commands
+func TestRunErrors(t *testing.T) {
+	for _, tc := range runErrorTests {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run(tc.args, &stdout, &stderr); status != tc.expected {
+				t.Errorf("Status was (%v) should be (%v)", status, tc.expected)
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, path string, bounds image.Rectangle) {
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	defer file.Close()
+	if filepath.Ext(path) != ".png" {
+		return
+	}
+	if err := png.Encode(file, image.NewRGBA(bounds)); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+}
@@ -4,29 +4,23 @@ import (
 	"container/list"
 	"fmt"
 	"math/rand"
-	"sort"
 
 	"github.com/go-gl/mathgl/mgl32"
 	"github.com/leedenison/gologo"
 	"github.com/leedenison/gologo/obj"
-	"github.com/leedenison/gologo/tags"
+	"github.com/leedenison/gologo/time"
 )
 
 var (
-	tagged       = tags.TagSet{}
-	callbackMap  = []*Maze{}
-	lastCallback = int(0)
+	callbackMap = []*Maze{}
 )
 
-func mazeTickCallback(tick int) {
-	if tick-lastCallback > DEFAULT_TICK_INCREMENT {
-		for _, maze := range callbackMap {
-			if maze.Callback != nil && !HasRemainingMoves(maze) && !IsFinished(maze) {
-				maze.Callback(maze)
-			}
-			maze.DoMove()
+func mazeTimerCallback() {
+	for _, maze := range callbackMap {
+		if maze.Callback != nil && !HasRemainingMoves(maze) && !IsFinished(maze) {
+			maze.Callback(maze)
 		}
-		lastCallback = tick
+		maze.DoMove()
 	}
 }
 
@@ -40,6 +34,7 @@ var DEFAULT_TICK_INCREMENT = 200
 
 type Maze struct {
 	Gologo         *gologo.Gologo
+	Scene          *gologo.Scene
 	Size           [2]int
 	Start          [2]int
 	End            [2]int
@@ -157,18 +152,9 @@ func Run(x, y int, callback func(*Maze)) {
 	maze := GenerateMaze(g, [2]int{x, y}, callback)
 	callbackMap = append(callbackMap, maze)
 
-	for !g.Window.ShouldClose() {
-		g.ClearBackBuffer()
-
-		r := tagged.GetAll("render")
-		sort.Sort(gologo.ByZOrder(r))
-		for _, object := range r {
-			object.Draw()
-		}
-
-		g.Window.SwapBuffers()
-		g.CheckForEvents()
-	}
+	g.SetScene(maze.Scene)
+	time.Every(DEFAULT_TICK_INCREMENT, mazeTimerCallback)
+	g.Run(nil)
 }
 
 func GenerateMaze(g *gologo.Gologo, size [2]int, callback func(*Maze)) *Maze {
@@ -195,7 +181,7 @@ func GenerateMaze(g *gologo.Gologo, size [2]int, callback func(*Maze)) *Maze {
 }
 
 func calcRenderSize(maze *Maze) {
-	winX, winY := maze.Gologo.Window.GetSize()
+	winX, winY := maze.Gologo.GetWindowSize()
 	windowCenter := maze.Gologo.GetWindowCenter()
 	screenDim := float32(winX)
 	if screenDim > float32(winY) {
@@ -217,6 +203,7 @@ func calcRenderSize(maze *Maze) {
 func initializeMaze(g *gologo.Gologo, size [2]int) *Maze {
 	result := &Maze{
 		Gologo: g,
+		Scene:  gologo.NewScene(),
 		Size:   size,
 		Start: [2]int{
 			rand.Intn(size[0]),
@@ -248,14 +235,16 @@ func initializeMaze(g *gologo.Gologo, size [2]int) *Maze {
 	hWallsSize := [2]int{size[0], size[1] - 1}
 	vWallsSize := [2]int{size[0] - 1, size[1]}
 
-	initializeBorder(result.Size, result.BottomLeft, result.RoomSize, halfWallWidth)
+	initializeBorder(result.Scene, result.Size, result.BottomLeft, result.RoomSize, halfWallWidth)
 	result.HWalls = initializeWalls(
+		result.Scene,
 		hWallsSize,
 		horizontalWall,
 		result.BottomLeft,
 		result.RoomSize,
 		[2]float32{result.RoomSize / 2, result.RoomSize})
 	result.VWalls = initializeWalls(
+		result.Scene,
 		vWallsSize,
 		verticalWall,
 		result.BottomLeft,
@@ -292,7 +281,7 @@ func initializeStart(maze *Maze) *gologo.Object {
 			},
 		},
 		mgl32.Vec4{0.0, 1.0, 0.0, 1.0})
-	tagged.Tag(start, "render")
+	maze.Scene.Add(start)
 	return start
 }
 
@@ -311,10 +300,10 @@ func initializeEnd(maze *Maze) {
 			},
 		},
 		mgl32.Vec4{1.0, 0.0, 0.0, 1.0})
-	tagged.Tag(end, "render")
+	maze.Scene.Add(end)
 }
 
-func initializeBorder(mazeSize [2]int, bottomLeft [2]float32, roomSize float32, halfWallWidth float32) {
+func initializeBorder(scene *gologo.Scene, mazeSize [2]int, bottomLeft [2]float32, roomSize float32, halfWallWidth float32) {
 	bottom := obj.Rectangle(
 		gologo.Rect{
 			{
@@ -367,13 +356,11 @@ func initializeBorder(mazeSize [2]int, bottomLeft [2]float32, roomSize float32,
 		},
 		mgl32.Vec4{1.0, 1.0, 1.0, 1.0})
 
-	tagged.Tag(bottom, "render")
-	tagged.Tag(top, "render")
-	tagged.Tag(left, "render")
-	tagged.Tag(right, "render")
+	scene.Add(bottom, top, left, right)
 }
 
 func initializeWalls(
+	scene *gologo.Scene,
 	size [2]int,
 	wall *gologo.Object,
 	mazeOffset [2]float32,
@@ -385,12 +372,12 @@ func initializeWalls(
 		result[i] = make([]*gologo.Object, size[1])
 		for j := range result[i] {
 			result[i][j] = wall.Clone()
-			tagged.Tag(result[i][j], "render")
 			result[i][j].Position = mgl32.Vec3{
 				mazeOffset[0] + float32(i)*roomSize + wallOffset[0],
 				mazeOffset[1] + float32(j)*roomSize + wallOffset[1],
 				0.0,
 			}
+			scene.Add(result[i][j])
 		}
 	}
 	return result
@@ -479,19 +466,19 @@ func removeIfCompleted(maze *Maze, rooms [][]bool, roomsList *list.List, room [2
 
 func removeWall(maze *Maze, from [2]int, to [2]int) {
 	if from[0] < to[0] {
-		tagged.Untag(maze.VWalls[from[0]][from[1]], "render")
+		maze.Scene.Remove(maze.VWalls[from[0]][from[1]])
 		maze.VWalls[from[0]][from[1]] = nil
 	}
 	if from[0] > to[0] {
-		tagged.Untag(maze.VWalls[to[0]][to[1]], "render")
+		maze.Scene.Remove(maze.VWalls[to[0]][to[1]])
 		maze.VWalls[to[0]][to[1]] = nil
 	}
 	if from[1] < to[1] {
-		tagged.Untag(maze.HWalls[from[0]][from[1]], "render")
+		maze.Scene.Remove(maze.HWalls[from[0]][from[1]])
 		maze.HWalls[from[0]][from[1]] = nil
 	}
 	if from[1] > to[1] {
-		tagged.Untag(maze.HWalls[to[0]][to[1]], "render")
+		maze.Scene.Remove(maze.HWalls[to[0]][to[1]])
 		maze.HWalls[to[0]][to[1]] = nil
 	}
 }
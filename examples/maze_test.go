@@ -0,0 +1,32 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/leedenison/gologo"
+)
+
+// TestGenerateMazeHeadless : Test that a maze can be generated and drawn
+// without a window, and that the start and end rooms are visible
+func TestGenerateMazeHeadless(t *testing.T) {
+	g := gologo.InitWithConfig(gologo.Config{Width: 200, Height: 200, Headless: true})
+	defer g.Close()
+
+	maze := GenerateMaze(g, [2]int{4, 4}, nil)
+
+	g.ClearBackBuffer()
+	maze.Scene.Draw()
+
+	startX, startY := maze.Player.GetPosition()
+	i := g.Frame.PixOffset(int(startX), 200-int(startY))
+	if g.Frame.Pix[i] != 0 || g.Frame.Pix[i+1] != 255 || g.Frame.Pix[i+2] != 0 {
+		t.Errorf("Start room pixel was %v should be green", g.Frame.Pix[i:i+4])
+	}
+
+	endX := maze.BottomLeft[0] + (float32(maze.End[0])+0.5)*maze.RoomSize
+	endY := maze.BottomLeft[1] + (float32(maze.End[1])+0.5)*maze.RoomSize
+	i = g.Frame.PixOffset(int(endX), 200-int(endY))
+	if g.Frame.Pix[i] != 255 || g.Frame.Pix[i+1] != 0 || g.Frame.Pix[i+2] != 0 {
+		t.Errorf("End room pixel was %v should be red", g.Frame.Pix[i:i+4])
+	}
+}
@@ -9,4 +9,7 @@ require (
 	github.com/pkg/errors v0.9.1
 )
 
-require golang.org/x/image v0.0.0-20190321063152-3fc05d484e9f // indirect
+require (
+	golang.org/x/image v0.0.0-20190321063152-3fc05d484e9f
+	golang.org/x/text v0.3.0 // indirect
+)
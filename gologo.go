@@ -18,30 +18,72 @@
 //		defer g.Close()
 //
 //		// Loop and check if the window has been closed
-//		for !g.Window.ShouldClose() {
+//		for !g.ShouldClose() {
 //			g.CheckForEvents()
 //		}
 //	}
+//
+// Alternatively Run provides a game loop which draws the objects added to
+// the current Scene with Add and calls update at a fixed rate:
+//
+//	g := gologo.Init()
+//	defer g.Close()
+//
+//	square := obj.Rectangle(gologo.Rect{{0, 0}, {50, 50}}, mgl32.Vec4{1, 0, 0, 1})
+//	g.Add(square)
+//
+//	g.Run(func(dt float64) {
+//		square.Translate(float32(100*dt), 0)
+//	})
+//
+// Setting Config.Headless renders into an image using a software
+// rasterizer instead of a window, so that programs can run without a GPU,
+// for example in tests.
 package gologo
 
 import (
+	"image"
 	"os"
 	"runtime"
 
 	"github.com/go-gl/glfw/v3.2/glfw"
+	"github.com/leedenison/gologo/input"
 	"github.com/leedenison/gologo/log"
 	"github.com/leedenison/gologo/render"
 	"github.com/leedenison/gologo/time"
 )
 
+// Gologo : Window is nil when running headless, in which case Frame
+// holds the image that is rendered into by the software rasterizer.
 type Gologo struct {
 	Window *glfw.Window
+	Frame  *image.RGBA
+	size   [2]int
+	closed bool
+
+	updateRate    int
+	accumulator   float64
+	scene         *Scene
+	updates       []func(dt float64)
+	tickCallbacks []func(tick int)
+	scripts       []*Script
 }
 
+// Config : Headless renders into an image of Width x Height pixels
+// without creating a window or OpenGL context.  Backend optionally
+// replaces the default backend, which is OpenGL for a window and the
+// software rasterizer when headless.  UpdateRate is the number of fixed
+// updates per second made by Run, 60 by default.  Clock optionally
+// replaces the source of the game clock, for example with a
+// time.ManualClock for deterministic tests.
 type Config struct {
-	Width  int
-	Height int
-	Title  string
+	Width      int
+	Height     int
+	Title      string
+	Headless   bool
+	Backend    render.Backend
+	UpdateRate int
+	Clock      time.Clock
 }
 
 const (
@@ -69,6 +111,10 @@ func InitWithConfig(config Config) *Gologo {
 	// Use io.Discard to disable
 	log.InitLogger(os.Stdout, os.Stdout, os.Stdout, os.Stderr)
 
+	if config.Headless {
+		return initHeadless(config)
+	}
+
 	if err := glfw.Init(); err != nil {
 		log.Error.Fatalln("glfw.Init failed:", err)
 	}
@@ -77,25 +123,94 @@ func InitWithConfig(config Config) *Gologo {
 	if err != nil {
 		log.Error.Fatalln("window.CreateWindow failed:", err)
 	}
+	input.Attach(window)
 
 	width, height := window.GetSize()
 	render.Set2DProjection(float32(width), float32(height))
 
-	if err := render.InitOpenGL(); err != nil {
-		log.Error.Fatalln("InitOpenGL failed:", err)
+	backend := config.Backend
+	if backend == nil {
+		backend = &render.OpenGLBackend{}
+	}
+
+	if err := render.Init(backend); err != nil {
+		log.Error.Fatalln("render.Init failed:", err)
 	}
 
 	if err := time.InitTick(); err != nil {
 		log.Error.Fatalln("InitTick failed:", err)
 	}
+	initClock(config)
 
 	return &Gologo{
-		Window: window,
+		Window:     window,
+		updateRate: updateRate(config),
+		scene:      NewScene(),
+	}
+}
+
+func initHeadless(config Config) *Gologo {
+	if config.Width == 0 || config.Height == 0 {
+		config.Width = defaultWinSizeX
+		config.Height = defaultWinSizeY
+	}
+
+	var frame *image.RGBA
+	backend := config.Backend
+	if backend == nil {
+		software := render.NewSoftwareBackend(config.Width, config.Height)
+		frame = software.Target
+		backend = software
+	}
+
+	if err := render.Init(backend); err != nil {
+		log.Error.Fatalln("render.Init failed:", err)
+	}
+
+	render.Set2DProjection(float32(config.Width), float32(config.Height))
+
+	if err := time.InitHeadlessTick(); err != nil {
+		log.Error.Fatalln("InitHeadlessTick failed:", err)
+	}
+	initClock(config)
+
+	return &Gologo{
+		Frame:      frame,
+		size:       [2]int{config.Width, config.Height},
+		updateRate: updateRate(config),
+		scene:      NewScene(),
+	}
+}
+
+func initClock(config Config) {
+	if config.Clock != nil {
+		time.SetClock(config.Clock)
+	}
+}
+
+func updateRate(config Config) int {
+	if config.UpdateRate <= 0 {
+		return defaultUpdateRate
 	}
+	return config.UpdateRate
+}
+
+// IsHeadless : Returns true if rendering to an image rather than a window
+func (g *Gologo) IsHeadless() bool {
+	return g.Window == nil
+}
+
+// GetWindowSize : Returns the size of the window, or of the frame
+// when running headless
+func (g *Gologo) GetWindowSize() (int, int) {
+	if g.IsHeadless() {
+		return g.size[0], g.size[1]
+	}
+	return g.Window.GetSize()
 }
 
 func (g *Gologo) GetWindowCenter() [2]float32 {
-	width, height := g.Window.GetSize()
+	width, height := g.GetWindowSize()
 	return [2]float32{
 		float32(width) / 2.0,
 		float32(height) / 2.0,
@@ -107,10 +222,55 @@ func (g *Gologo) ClearBackBuffer() {
 	render.ClearBackBuffer()
 }
 
+// ShouldClose : Returns true once the window has been asked to close
+func (g *Gologo) ShouldClose() bool {
+	if g.IsHeadless() {
+		return g.closed
+	}
+	return g.Window.ShouldClose()
+}
+
+// SetShouldClose : Sets whether the window should close
+func (g *Gologo) SetShouldClose(value bool) {
+	if g.IsHeadless() {
+		g.closed = value
+		return
+	}
+	g.Window.SetShouldClose(value)
+}
+
+// SwapBuffers : Displays the frame which has been drawn.  When headless
+// the frame is drawn directly into Frame so there is nothing to swap.
+func (g *Gologo) SwapBuffers() {
+	if !g.IsHeadless() {
+		g.Window.SwapBuffers()
+	}
+}
+
+// CheckForEvents : Starts a new input frame and processes pending window
+// events.  Closes the window if input.ActionClose was pressed.
 func (g *Gologo) CheckForEvents() {
-	glfw.PollEvents()
+	input.ClearPressed()
+	g.pollEvents()
+}
+
+// pollEvents : Processes replayed and pending window events without
+// clearing the keys and buttons just pressed or released.  Closes the
+// window if input.ActionClose was pressed.
+func (g *Gologo) pollEvents() {
+	input.UpdateReplay()
+
+	if !g.IsHeadless() {
+		glfw.PollEvents()
+	}
+
+	if input.ActionJustPressed(input.ActionClose) {
+		g.SetShouldClose(true)
+	}
 }
 
 func (g *Gologo) Close() {
-	glfw.Terminate()
+	if !g.IsHeadless() {
+		glfw.Terminate()
+	}
 }
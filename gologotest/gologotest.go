@@ -0,0 +1,192 @@
+// Package gologotest provides golden image testing of gologo scenes.
+//
+// Scenes are rendered with the headless software rasterizer and compared
+// against PNG images checked in under the testdata directory of the package
+// under test.  Run the tests of a package with the -update flag to
+// regenerate its golden images, for example:
+//
+//	go test ./obj -update
+//
+// A minimal golden image test might be:
+//
+//	func TestScene(t *testing.T) {
+//		g := gologotest.Init(320, 240)
+//		defer g.Close()
+//
+//		square := obj.Rectangle(
+//			gologo.Rect{{10, 10}, {50, 50}},
+//			mgl32.Vec4{1.0, 0.0, 0.0, 1.0})
+//
+//		gologotest.AssertScene(t, g, "square", []*gologo.Object{square}, 0)
+//	}
+package gologotest
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/leedenison/gologo"
+)
+
+var update = flag.Bool("update", false, "update golden images")
+
+// GoldenDir : The directory golden images are read from and written to,
+// relative to the package under test
+var GoldenDir = "testdata"
+
+// Init : Initialises gologo to render headless into a width x height frame
+func Init(width int, height int) *gologo.Gologo {
+	return gologo.InitWithConfig(gologo.Config{
+		Width:    width,
+		Height:   height,
+		Headless: true,
+	})
+}
+
+// Render : Clears the frame and draws the objects in z-order, returning
+// a copy of the resulting frame
+func Render(g *gologo.Gologo, objects []*gologo.Object) *image.RGBA {
+	sorted := make([]*gologo.Object, len(objects))
+	copy(sorted, objects)
+	sort.Stable(gologo.ByZOrder(sorted))
+
+	g.ClearBackBuffer()
+	for _, object := range sorted {
+		object.Draw()
+	}
+
+	result := image.NewRGBA(g.Frame.Rect)
+	copy(result.Pix, g.Frame.Pix)
+	return result
+}
+
+// AssertScene : Renders the objects and compares the frame with the named
+// golden image.  See AssertGolden.
+func AssertScene(
+	t testing.TB,
+	g *gologo.Gologo,
+	name string,
+	objects []*gologo.Object,
+	tolerance uint8,
+) {
+	t.Helper()
+	AssertGolden(t, name, Render(g, objects), tolerance)
+}
+
+// AssertGolden : Compares the image with the named golden image, failing
+// the test if any channel of any pixel differs by more than tolerance.
+// On failure the actual image and a diff image highlighting the differing
+// pixels in red are written alongside the golden image.  With -update the
+// golden image is replaced by the actual image instead.
+func AssertGolden(t testing.TB, name string, actual *image.RGBA, tolerance uint8) {
+	t.Helper()
+
+	goldenPath := filepath.Join(GoldenDir, name+".png")
+
+	if *update {
+		if err := writePNG(goldenPath, actual); err != nil {
+			t.Fatalf("Failed to update golden image: %v", err)
+		}
+		return
+	}
+
+	golden, err := readPNG(goldenPath)
+	if err != nil {
+		t.Fatalf("Failed to read golden image (run with -update to create it): %v", err)
+	}
+
+	diff, count := Diff(golden, actual, tolerance)
+	if count == 0 {
+		return
+	}
+
+	actualPath := filepath.Join(GoldenDir, name+".actual.png")
+	diffPath := filepath.Join(GoldenDir, name+".diff.png")
+	if err := writePNG(actualPath, actual); err != nil {
+		t.Errorf("Failed to write actual image: %v", err)
+	}
+	if err := writePNG(diffPath, diff); err != nil {
+		t.Errorf("Failed to write diff image: %v", err)
+	}
+
+	t.Errorf("Image differs from golden image %v in %v pixels, see %v and %v",
+		goldenPath, count, actualPath, diffPath)
+}
+
+// Diff : Returns an image in which pixels that differ by more than
+// tolerance in any channel are red, and matching pixels are a faded copy
+// of the expected image, along with the count of differing pixels.
+// Pixels inside only one of the images always differ.
+func Diff(expected image.Image, actual image.Image, tolerance uint8) (*image.RGBA, int) {
+	bounds := expected.Bounds().Union(actual.Bounds())
+	diff := image.NewRGBA(bounds)
+	count := 0
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			p := image.Point{x, y}
+			if !p.In(expected.Bounds()) || !p.In(actual.Bounds()) {
+				diff.SetRGBA(x, y, color.RGBA{255, 0, 0, 255})
+				count++
+				continue
+			}
+
+			e := color.RGBAModel.Convert(expected.At(x, y)).(color.RGBA)
+			a := color.RGBAModel.Convert(actual.At(x, y)).(color.RGBA)
+			if channelDiff(e.R, a.R) > tolerance ||
+				channelDiff(e.G, a.G) > tolerance ||
+				channelDiff(e.B, a.B) > tolerance ||
+				channelDiff(e.A, a.A) > tolerance {
+				diff.SetRGBA(x, y, color.RGBA{255, 0, 0, 255})
+				count++
+				continue
+			}
+
+			diff.SetRGBA(x, y, color.RGBA{e.R / 4, e.G / 4, e.B / 4, 255})
+		}
+	}
+
+	return diff, count
+}
+
+func channelDiff(a uint8, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func readPNG(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return png.Decode(file)
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to encode %q: %v", path, err)
+	}
+
+	return file.Close()
+}
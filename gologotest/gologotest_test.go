@@ -0,0 +1,103 @@
+package gologotest
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var diffTests = []struct {
+	name      string
+	actual    color.RGBA
+	tolerance uint8
+	count     int
+}{
+	{"identical", color.RGBA{100, 100, 100, 255}, 0, 0},
+	{"within tolerance", color.RGBA{102, 98, 100, 255}, 2, 0},
+	{"outside tolerance", color.RGBA{103, 100, 100, 255}, 2, 4},
+}
+
+// TestDiff : Test that pixels are only counted as differing when a channel
+// differs by more than the tolerance
+func TestDiff(t *testing.T) {
+	for _, tc := range diffTests {
+		t.Run(tc.name, func(t *testing.T) {
+			expected := solidImage(2, 2, color.RGBA{100, 100, 100, 255})
+			actual := solidImage(2, 2, tc.actual)
+
+			_, count := Diff(expected, actual, tc.tolerance)
+			if count != tc.count {
+				t.Errorf("Differing pixels was (%v) should be (%v)", count, tc.count)
+			}
+		})
+	}
+
+	if _, count := Diff(solidImage(2, 2, color.RGBA{}), solidImage(3, 2, color.RGBA{}), 0); count != 2 {
+		t.Errorf("Differing pixels for different sizes was (%v) should be (2)", count)
+	}
+}
+
+// failureRecorder : Records whether a test would have failed instead of
+// failing it
+type failureRecorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *failureRecorder) Errorf(format string, args ...interface{}) {
+	r.failed = true
+}
+
+var assertGoldenTests = []struct {
+	name      string
+	actual    color.RGBA
+	tolerance uint8
+	failed    bool
+}{
+	{"matching", color.RGBA{100, 100, 100, 255}, 0, false},
+	{"within tolerance", color.RGBA{101, 100, 100, 255}, 1, false},
+	{"differing", color.RGBA{0, 0, 255, 255}, 0, true},
+}
+
+// TestAssertGolden : Test that images are compared with the golden image,
+// and that the actual and diff images are only written on failure
+func TestAssertGolden(t *testing.T) {
+	defer func(dir string) { GoldenDir = dir }(GoldenDir)
+	GoldenDir = t.TempDir()
+
+	if err := writePNG(filepath.Join(GoldenDir, "golden.png"), solidImage(4, 4, color.RGBA{100, 100, 100, 255})); err != nil {
+		t.Fatalf("writePNG failed: %v", err)
+	}
+
+	for _, tc := range assertGoldenTests {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Remove(filepath.Join(GoldenDir, "golden.actual.png"))
+			os.Remove(filepath.Join(GoldenDir, "golden.diff.png"))
+
+			recorder := &failureRecorder{TB: t}
+			AssertGolden(recorder, "golden", solidImage(4, 4, tc.actual), tc.tolerance)
+			if recorder.failed != tc.failed {
+				t.Errorf("Failed was (%v) should be (%v)", recorder.failed, tc.failed)
+			}
+
+			for _, name := range []string{"golden.actual.png", "golden.diff.png"} {
+				_, err := os.Stat(filepath.Join(GoldenDir, name))
+				if written := err == nil; written != tc.failed {
+					t.Errorf("%v written was (%v) should be (%v)", name, written, tc.failed)
+				}
+			}
+		})
+	}
+}
+
+func solidImage(width int, height int, c color.RGBA) *image.RGBA {
+	rgba := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			rgba.SetRGBA(x, y, c)
+		}
+	}
+	return rgba
+}
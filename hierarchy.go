@@ -0,0 +1,46 @@
+package gologo
+
+// AddChild : Attaches the child to the object so that the child's position,
+// orientation and scale are relative to the object.  The child is detached
+// from any previous parent and added, with its descendants, to the scenes
+// containing the object.
+func (o *Object) AddChild(child *Object) {
+	for ancestor := o; ancestor != nil; ancestor = ancestor.Parent {
+		if ancestor == child {
+			panic("AddChild: child is an ancestor of the object")
+		}
+	}
+
+	if child.Parent != nil {
+		child.Parent.RemoveChild(child)
+	}
+
+	child.Parent = o
+	o.Children = append(o.Children, child)
+
+	for _, scene := range o.scenes {
+		scene.Add(child)
+	}
+}
+
+// RemoveChild : Detaches the child from the object.  The child remains in
+// any scenes it has been added to.
+func (o *Object) RemoveChild(child *Object) {
+	for i, c := range o.Children {
+		if c == child {
+			o.Children = append(o.Children[:i], o.Children[i+1:]...)
+			child.Parent = nil
+			return
+		}
+	}
+}
+
+// Descendants : Returns the children of the object, their children and so on
+func (o *Object) Descendants() []*Object {
+	result := []*Object{}
+	for _, child := range o.Children {
+		result = append(result, child)
+		result = append(result, child.Descendants()...)
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+package gologo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func createTank() (*Object, *Object) {
+	tank := CreateObject(mgl32.Vec3{100, 100, 0})
+	tank.Scale = 2.0
+	tank.Orientation = math.Pi / 2
+
+	turret := CreateObject(mgl32.Vec3{10, 0, 0})
+	turret.Scale = 1.0
+	tank.AddChild(turret)
+
+	return tank, turret
+}
+
+// TestChildWorldSpace : Test that a child's model is relative to its parent
+// and that world and local space conversions are inverses
+func TestChildWorldSpace(t *testing.T) {
+	tank, turret := createTank()
+
+	// Rotated 90 degrees and scaled by 2, so 10 along x is 20 along y
+	expected := mgl32.Vec3{100, 120, 0}
+	if position := turret.WorldPosition(); !position.ApproxEqualThreshold(expected, epsilon) {
+		t.Errorf("World position was %v should be %v", position, expected)
+	}
+
+	local := turret.LocalSpace(mgl32.Vec3{50, 60, 0})
+	if world := turret.WorldSpace(local); !world.ApproxEqualThreshold(mgl32.Vec3{50, 60, 0}, epsilon) {
+		t.Errorf("Round trip through local space was %v should be %v", world, mgl32.Vec3{50, 60, 0})
+	}
+
+	turret.SetWorldPosition(mgl32.Vec3{100, 100, 0})
+	if !turret.Position.ApproxEqualThreshold(mgl32.Vec3{}, epsilon) {
+		t.Errorf("Position after SetWorldPosition was %v should be the parent origin", turret.Position)
+	}
+
+	if orientation := turret.WorldOrientation(); math.Abs(orientation-math.Pi/2) > epsilon {
+		t.Errorf("World orientation was (%v) should be (%v)", orientation, math.Pi/2)
+	}
+
+	tank.RemoveChild(turret)
+	if turret.Parent != nil || len(tank.Children) != 0 {
+		t.Errorf("Child was not detached")
+	}
+}
+
+// TestCloneChildren : Test that cloning an object deep clones its children
+func TestCloneChildren(t *testing.T) {
+	tank, turret := createTank()
+
+	tankCopy := tank.Clone()
+	if len(tankCopy.Children) != 1 {
+		t.Fatalf("Clone had (%v) children should have (1)", len(tankCopy.Children))
+	}
+
+	turretCopy := tankCopy.Children[0]
+	if turretCopy == turret || turretCopy.Parent != tankCopy {
+		t.Errorf("Child was not cloned and attached to the copy")
+	}
+
+	tankCopy.Translate(50, 0)
+	if position := turret.WorldPosition(); !position.ApproxEqualThreshold(mgl32.Vec3{100, 120, 0}, epsilon) {
+		t.Errorf("Moving the clone moved the original child to %v", position)
+	}
+}
+
+// TestSceneSubtree : Test that adding or removing a parent from a scene
+// adds or removes its descendants
+func TestSceneSubtree(t *testing.T) {
+	tank, turret := createTank()
+	barrel := CreateObject(mgl32.Vec3{5, 0, 0})
+	turret.AddChild(barrel)
+
+	scene := NewScene()
+	scene.Add(tank)
+	if scene.Len() != 3 {
+		t.Errorf("Scene had (%v) objects should have (3)", scene.Len())
+	}
+
+	light := CreateObject(mgl32.Vec3{})
+	tank.AddChild(light)
+	if !scene.Contains(light) {
+		t.Errorf("Child attached to an object in the scene was not added")
+	}
+
+	scene.Remove(tank)
+	if scene.Len() != 0 {
+		t.Errorf("Scene had (%v) objects after removing the parent should have (0)", scene.Len())
+	}
+}
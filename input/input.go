@@ -0,0 +1,336 @@
+// Package input records keyboard and mouse state for gologo programs.
+//
+// Key state is updated as events are received and can be queried at any
+// time with IsDown, or per frame with JustPressed and JustReleased:
+//
+//	if input.JustPressed(input.KeySpace) {
+//		// Space was pressed since the last frame
+//	}
+//
+// When the gologo game loop is run with Step, JustPressed and JustReleased
+// are instead cleared after each fixed update, so that each press is seen
+// by exactly one update function.
+//
+// Named actions may be bound to one or more keys and rebound from a
+// configuration file, so that programs need not refer to keys directly:
+//
+//	input.Bind("jump", input.KeySpace, input.KeyW)
+//	if input.ActionJustPressed("jump") {
+//		...
+//	}
+//
+// ActionClose is bound to Escape by default and closes the window.
+// Call Unbind(ActionClose) to disable this.
+//
+// Input may be recorded with the tick time of each event and replayed
+// later through the same code paths as events from the window:
+//
+//	recording := input.StartRecording()
+//	...
+//	input.StopRecording().Save("bug.rec")
+//
+//	recording, err := input.LoadRecording("bug.rec")
+//	input.Replay(recording)
+//
+// Tests may also inject events directly with InjectKey, InjectMouseButton,
+// InjectCursor and InjectScroll.
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/go-gl/glfw/v3.2/glfw"
+)
+
+// Action : Whether a key was pressed, released or held until it repeated
+type Action = glfw.Action
+
+const (
+	Release = glfw.Release
+	Press   = glfw.Press
+	Repeat  = glfw.Repeat
+)
+
+// ModifierKey : The modifier keys held when a key event occurred
+type ModifierKey = glfw.ModifierKey
+
+const (
+	ModShift   = glfw.ModShift
+	ModControl = glfw.ModControl
+	ModAlt     = glfw.ModAlt
+	ModSuper   = glfw.ModSuper
+)
+
+// ActionClose : The action which closes the window, bound to Escape by default
+const ActionClose = "close"
+
+// KeyEvent : A key being pressed, released or repeated
+type KeyEvent struct {
+	Key      Key
+	Scancode int
+	Action   Action
+	Mods     ModifierKey
+}
+
+// InputState : Stores the key state, callbacks and action bindings
+type InputState struct {
+	Down         map[Key]bool
+	Pressed      map[Key]bool
+	Released     map[Key]bool
+	KeyCallbacks []func(KeyEvent)
+	Bindings     map[string][]Key
+}
+
+var inputState = newInputState()
+
+func newInputState() *InputState {
+	return &InputState{
+		Down:     map[Key]bool{},
+		Pressed:  map[Key]bool{},
+		Released: map[Key]bool{},
+		Bindings: map[string][]Key{
+			ActionClose: {KeyEscape},
+		},
+	}
+}
+
+// Reset : Clears all key and mouse state, callbacks and bindings,
+// restoring the default bindings, and stops any recording or replay
+func Reset() {
+	inputState = newInputState()
+	mouseState = newMouseState()
+	recording = nil
+	player = nil
+}
+
+// Attach : Receives key and mouse events from the window
+func Attach(window *glfw.Window) {
+	window.SetKeyCallback(keyCallback)
+	window.SetMouseButtonCallback(mouseButtonCallback)
+	window.SetCursorPosCallback(cursorPosCallback)
+	window.SetScrollCallback(scrollCallback)
+}
+
+// Update : Starts a new frame, clearing the keys and buttons which were
+// just pressed or released and the scroll offset, then dispatches any
+// replayed events which are due.  Call once per frame before polling for
+// events.
+func Update() {
+	ClearPressed()
+	UpdateReplay()
+}
+
+// ClearPressed : Clears the keys and buttons which were just pressed or
+// released and the scroll offset.  Game loops which read input at a fixed
+// update rate rather than once per frame call this after each update, so
+// that each press is seen by exactly one update.
+func ClearPressed() {
+	inputState.Pressed = map[Key]bool{}
+	inputState.Released = map[Key]bool{}
+	updateMouse()
+}
+
+// UpdateReplay : Dispatches any replayed events which are due.  Call once
+// per frame before polling for events.
+func UpdateReplay() {
+	if player != nil {
+		player.update()
+	}
+}
+
+func keyCallback(
+	window *glfw.Window,
+	key glfw.Key,
+	scancode int,
+	action glfw.Action,
+	mods glfw.ModifierKey,
+) {
+	if !live() {
+		return
+	}
+
+	HandleKey(KeyEvent{
+		Key:      key,
+		Scancode: scancode,
+		Action:   action,
+		Mods:     mods,
+	})
+}
+
+// HandleKey : Updates the key state from the event and calls the key
+// callbacks.  Called for each key event received from the window.
+func HandleKey(event KeyEvent) {
+	record(Event{Type: EventKey, Key: event})
+
+	switch event.Action {
+	case Press:
+		if !inputState.Down[event.Key] {
+			inputState.Pressed[event.Key] = true
+		}
+		inputState.Down[event.Key] = true
+	case Release:
+		if inputState.Down[event.Key] {
+			inputState.Released[event.Key] = true
+		}
+		delete(inputState.Down, event.Key)
+	}
+
+	for _, callback := range inputState.KeyCallbacks {
+		callback(event)
+	}
+}
+
+// OnKey : Registers a function to be called with every key event
+func OnKey(callback func(KeyEvent)) {
+	inputState.KeyCallbacks = append(inputState.KeyCallbacks, callback)
+}
+
+// IsDown : Returns true if the key is currently held down
+func IsDown(key Key) bool {
+	return inputState.Down[key]
+}
+
+// JustPressed : Returns true if the key was pressed since the last frame
+func JustPressed(key Key) bool {
+	return inputState.Pressed[key]
+}
+
+// JustReleased : Returns true if the key was released since the last frame
+func JustReleased(key Key) bool {
+	return inputState.Released[key]
+}
+
+/////////////////////////////////////////////////////////////
+// Actions
+//
+
+// Bind : Binds the action to the keys, replacing any existing binding
+func Bind(action string, keys ...Key) {
+	inputState.Bindings[action] = keys
+}
+
+// Unbind : Removes the binding for the action
+func Unbind(action string) {
+	delete(inputState.Bindings, action)
+}
+
+// Bindings : Returns the keys bound to the action
+func Bindings(action string) []Key {
+	return inputState.Bindings[action]
+}
+
+// ActionDown : Returns true if any key bound to the action is held down
+func ActionDown(action string) bool {
+	return anyKey(action, IsDown)
+}
+
+// ActionJustPressed : Returns true if any key bound to the action was
+// pressed since the last frame
+func ActionJustPressed(action string) bool {
+	return anyKey(action, JustPressed)
+}
+
+// ActionJustReleased : Returns true if any key bound to the action was
+// released since the last frame
+func ActionJustReleased(action string) bool {
+	return anyKey(action, JustReleased)
+}
+
+func anyKey(action string, test func(Key) bool) bool {
+	for _, key := range inputState.Bindings[action] {
+		if test(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyName : Returns the name of the key used in binding configuration
+// files, for example "SPACE" or "LEFT_SHIFT"
+func KeyName(key Key) string {
+	for name, k := range keyNames {
+		if k == key {
+			return name
+		}
+	}
+	return fmt.Sprintf("%d", int(key))
+}
+
+// ParseKey : Returns the key with the name used in binding configuration files
+func ParseKey(name string) (Key, error) {
+	key, ok := keyNames[name]
+	if !ok {
+		return KeyUnknown, fmt.Errorf("unknown key: %q", name)
+	}
+	return key, nil
+}
+
+// LoadBindings : Binds actions to keys from a JSON configuration file of
+// action names to lists of key names, for example:
+//
+//	{"jump": ["SPACE", "W"], "left": ["LEFT", "A"]}
+//
+// Actions in the file replace existing bindings; other actions are unchanged.
+func LoadBindings(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to load bindings %q: %v", path, err)
+	}
+
+	config := map[string][]string{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse bindings %q: %v", path, err)
+	}
+
+	bindings := map[string][]Key{}
+	for action, names := range config {
+		keys := []Key{}
+		for _, name := range names {
+			key, err := ParseKey(name)
+			if err != nil {
+				return fmt.Errorf("failed to parse bindings %q: %v", path, err)
+			}
+			keys = append(keys, key)
+		}
+		bindings[action] = keys
+	}
+
+	for action, keys := range bindings {
+		Bind(action, keys...)
+	}
+
+	return nil
+}
+
+// SaveBindings : Writes all action bindings to a JSON configuration file
+// in the format read by LoadBindings
+func SaveBindings(path string) error {
+	config := map[string][]string{}
+	for action, keys := range inputState.Bindings {
+		names := []string{}
+		for _, key := range keys {
+			names = append(names, KeyName(key))
+		}
+		config[action] = names
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Actions : Returns the names of all bound actions in sorted order
+func Actions() []string {
+	result := []string{}
+	for action := range inputState.Bindings {
+		result = append(result, action)
+	}
+	sort.Strings(result)
+	return result
+}
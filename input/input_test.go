@@ -0,0 +1,147 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKeyState(t *testing.T) {
+	Reset()
+
+	HandleKey(KeyEvent{Key: KeySpace, Action: Press})
+	if !IsDown(KeySpace) || !JustPressed(KeySpace) {
+		t.Errorf("Space was (down %v, pressed %v) should be (true, true)",
+			IsDown(KeySpace), JustPressed(KeySpace))
+	}
+
+	HandleKey(KeyEvent{Key: KeySpace, Action: Repeat})
+	Update()
+	if !IsDown(KeySpace) || JustPressed(KeySpace) {
+		t.Errorf("Held space was (down %v, pressed %v) should be (true, false)",
+			IsDown(KeySpace), JustPressed(KeySpace))
+	}
+
+	HandleKey(KeyEvent{Key: KeySpace, Action: Release})
+	if IsDown(KeySpace) || !JustReleased(KeySpace) {
+		t.Errorf("Released space was (down %v, released %v) should be (false, true)",
+			IsDown(KeySpace), JustReleased(KeySpace))
+	}
+}
+
+func TestOnKey(t *testing.T) {
+	Reset()
+
+	events := []KeyEvent{}
+	OnKey(func(event KeyEvent) {
+		events = append(events, event)
+	})
+
+	HandleKey(KeyEvent{Key: KeyA, Action: Press, Mods: ModShift})
+	HandleKey(KeyEvent{Key: KeyA, Action: Release})
+
+	if len(events) != 2 {
+		t.Fatalf("Events was (%v) should be (2)", len(events))
+	}
+	if events[0].Mods != ModShift {
+		t.Errorf("Mods was (%v) should be (%v)", events[0].Mods, ModShift)
+	}
+}
+
+func TestActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		pressed Key
+		want    bool
+	}{
+		{"primary", KeySpace, true},
+		{"secondary", KeyW, true},
+		{"unbound", KeyS, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			Reset()
+			Bind("jump", KeySpace, KeyW)
+
+			HandleKey(KeyEvent{Key: test.pressed, Action: Press})
+			if got := ActionJustPressed("jump"); got != test.want {
+				t.Errorf("ActionJustPressed was (%v) should be (%v)", got, test.want)
+			}
+			if got := ActionDown("jump"); got != test.want {
+				t.Errorf("ActionDown was (%v) should be (%v)", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDefaultClose(t *testing.T) {
+	Reset()
+
+	HandleKey(KeyEvent{Key: KeyEscape, Action: Press})
+	if !ActionJustPressed(ActionClose) {
+		t.Errorf("Escape should trigger %v by default", ActionClose)
+	}
+
+	Unbind(ActionClose)
+	if ActionJustPressed(ActionClose) {
+		t.Errorf("Escape should not trigger %v once unbound", ActionClose)
+	}
+}
+
+func TestBindingsFile(t *testing.T) {
+	Reset()
+	Bind("left", KeyLeft, KeyA)
+
+	path := filepath.Join(t.TempDir(), "bindings.json")
+	if err := SaveBindings(path); err != nil {
+		t.Fatalf("SaveBindings failed: %v", err)
+	}
+
+	Reset()
+	if err := LoadBindings(path); err != nil {
+		t.Fatalf("LoadBindings failed: %v", err)
+	}
+
+	keys := Bindings("left")
+	if len(keys) != 2 || keys[0] != KeyLeft || keys[1] != KeyA {
+		t.Errorf("Bindings was (%v) should be (%v)", keys, []Key{KeyLeft, KeyA})
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.json")
+	os.WriteFile(bad, []byte(`{"jump": ["NOT_A_KEY"]}`), 0o644)
+	if err := LoadBindings(bad); err == nil {
+		t.Errorf("LoadBindings should fail for unknown key names")
+	}
+}
+
+func TestMouseState(t *testing.T) {
+	Reset()
+
+	HandleCursor(CursorEvent{X: 10, Y: 20})
+	HandleMouseButton(MouseButtonEvent{Button: MouseButtonLeft, Action: Press})
+	HandleScroll(ScrollEvent{Y: 1})
+	HandleScroll(ScrollEvent{Y: 2})
+
+	if x, y := CursorPosition(); x != 10 || y != 20 {
+		t.Errorf("CursorPosition was (%v, %v) should be (10, 20)", x, y)
+	}
+	if !MouseDown(MouseButtonLeft) || !MouseJustPressed(MouseButtonLeft) {
+		t.Errorf("Left button was (down %v, pressed %v) should be (true, true)",
+			MouseDown(MouseButtonLeft), MouseJustPressed(MouseButtonLeft))
+	}
+	if _, y := Scroll(); y != 3 {
+		t.Errorf("Scroll was (%v) should be (3)", y)
+	}
+
+	Update()
+	HandleMouseButton(MouseButtonEvent{Button: MouseButtonLeft, Action: Release})
+
+	if MouseDown(MouseButtonLeft) || !MouseJustReleased(MouseButtonLeft) {
+		t.Errorf("Left button was (down %v, released %v) should be (false, true)",
+			MouseDown(MouseButtonLeft), MouseJustReleased(MouseButtonLeft))
+	}
+	if _, y := Scroll(); y != 0 {
+		t.Errorf("Scroll after Update was (%v) should be (0)", y)
+	}
+}
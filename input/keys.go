@@ -0,0 +1,259 @@
+package input
+
+import (
+	"github.com/go-gl/glfw/v3.2/glfw"
+)
+
+// Key : A keyboard key
+type Key = glfw.Key
+
+// Keys : The keyboard keys, named as in GLFW
+const (
+	KeyUnknown      = glfw.KeyUnknown
+	KeySpace        = glfw.KeySpace
+	KeyApostrophe   = glfw.KeyApostrophe
+	KeyComma        = glfw.KeyComma
+	KeyMinus        = glfw.KeyMinus
+	KeyPeriod       = glfw.KeyPeriod
+	KeySlash        = glfw.KeySlash
+	Key0            = glfw.Key0
+	Key1            = glfw.Key1
+	Key2            = glfw.Key2
+	Key3            = glfw.Key3
+	Key4            = glfw.Key4
+	Key5            = glfw.Key5
+	Key6            = glfw.Key6
+	Key7            = glfw.Key7
+	Key8            = glfw.Key8
+	Key9            = glfw.Key9
+	KeySemicolon    = glfw.KeySemicolon
+	KeyEqual        = glfw.KeyEqual
+	KeyA            = glfw.KeyA
+	KeyB            = glfw.KeyB
+	KeyC            = glfw.KeyC
+	KeyD            = glfw.KeyD
+	KeyE            = glfw.KeyE
+	KeyF            = glfw.KeyF
+	KeyG            = glfw.KeyG
+	KeyH            = glfw.KeyH
+	KeyI            = glfw.KeyI
+	KeyJ            = glfw.KeyJ
+	KeyK            = glfw.KeyK
+	KeyL            = glfw.KeyL
+	KeyM            = glfw.KeyM
+	KeyN            = glfw.KeyN
+	KeyO            = glfw.KeyO
+	KeyP            = glfw.KeyP
+	KeyQ            = glfw.KeyQ
+	KeyR            = glfw.KeyR
+	KeyS            = glfw.KeyS
+	KeyT            = glfw.KeyT
+	KeyU            = glfw.KeyU
+	KeyV            = glfw.KeyV
+	KeyW            = glfw.KeyW
+	KeyX            = glfw.KeyX
+	KeyY            = glfw.KeyY
+	KeyZ            = glfw.KeyZ
+	KeyLeftBracket  = glfw.KeyLeftBracket
+	KeyBackslash    = glfw.KeyBackslash
+	KeyRightBracket = glfw.KeyRightBracket
+	KeyGraveAccent  = glfw.KeyGraveAccent
+	KeyWorld1       = glfw.KeyWorld1
+	KeyWorld2       = glfw.KeyWorld2
+	KeyEscape       = glfw.KeyEscape
+	KeyEnter        = glfw.KeyEnter
+	KeyTab          = glfw.KeyTab
+	KeyBackspace    = glfw.KeyBackspace
+	KeyInsert       = glfw.KeyInsert
+	KeyDelete       = glfw.KeyDelete
+	KeyRight        = glfw.KeyRight
+	KeyLeft         = glfw.KeyLeft
+	KeyDown         = glfw.KeyDown
+	KeyUp           = glfw.KeyUp
+	KeyPageUp       = glfw.KeyPageUp
+	KeyPageDown     = glfw.KeyPageDown
+	KeyHome         = glfw.KeyHome
+	KeyEnd          = glfw.KeyEnd
+	KeyCapsLock     = glfw.KeyCapsLock
+	KeyScrollLock   = glfw.KeyScrollLock
+	KeyNumLock      = glfw.KeyNumLock
+	KeyPrintScreen  = glfw.KeyPrintScreen
+	KeyPause        = glfw.KeyPause
+	KeyF1           = glfw.KeyF1
+	KeyF2           = glfw.KeyF2
+	KeyF3           = glfw.KeyF3
+	KeyF4           = glfw.KeyF4
+	KeyF5           = glfw.KeyF5
+	KeyF6           = glfw.KeyF6
+	KeyF7           = glfw.KeyF7
+	KeyF8           = glfw.KeyF8
+	KeyF9           = glfw.KeyF9
+	KeyF10          = glfw.KeyF10
+	KeyF11          = glfw.KeyF11
+	KeyF12          = glfw.KeyF12
+	KeyF13          = glfw.KeyF13
+	KeyF14          = glfw.KeyF14
+	KeyF15          = glfw.KeyF15
+	KeyF16          = glfw.KeyF16
+	KeyF17          = glfw.KeyF17
+	KeyF18          = glfw.KeyF18
+	KeyF19          = glfw.KeyF19
+	KeyF20          = glfw.KeyF20
+	KeyF21          = glfw.KeyF21
+	KeyF22          = glfw.KeyF22
+	KeyF23          = glfw.KeyF23
+	KeyF24          = glfw.KeyF24
+	KeyF25          = glfw.KeyF25
+	KeyKP0          = glfw.KeyKP0
+	KeyKP1          = glfw.KeyKP1
+	KeyKP2          = glfw.KeyKP2
+	KeyKP3          = glfw.KeyKP3
+	KeyKP4          = glfw.KeyKP4
+	KeyKP5          = glfw.KeyKP5
+	KeyKP6          = glfw.KeyKP6
+	KeyKP7          = glfw.KeyKP7
+	KeyKP8          = glfw.KeyKP8
+	KeyKP9          = glfw.KeyKP9
+	KeyKPDecimal    = glfw.KeyKPDecimal
+	KeyKPDivide     = glfw.KeyKPDivide
+	KeyKPMultiply   = glfw.KeyKPMultiply
+	KeyKPSubtract   = glfw.KeyKPSubtract
+	KeyKPAdd        = glfw.KeyKPAdd
+	KeyKPEnter      = glfw.KeyKPEnter
+	KeyKPEqual      = glfw.KeyKPEqual
+	KeyLeftShift    = glfw.KeyLeftShift
+	KeyLeftControl  = glfw.KeyLeftControl
+	KeyLeftAlt      = glfw.KeyLeftAlt
+	KeyLeftSuper    = glfw.KeyLeftSuper
+	KeyRightShift   = glfw.KeyRightShift
+	KeyRightControl = glfw.KeyRightControl
+	KeyRightAlt     = glfw.KeyRightAlt
+	KeyRightSuper   = glfw.KeyRightSuper
+	KeyMenu         = glfw.KeyMenu
+)
+
+// keyNames : The names used for keys in binding configuration files,
+// as in the GLFW_KEY_ constants without the prefix
+var keyNames = map[string]Key{
+	"UNKNOWN":       KeyUnknown,
+	"SPACE":         KeySpace,
+	"APOSTROPHE":    KeyApostrophe,
+	"COMMA":         KeyComma,
+	"MINUS":         KeyMinus,
+	"PERIOD":        KeyPeriod,
+	"SLASH":         KeySlash,
+	"0":             Key0,
+	"1":             Key1,
+	"2":             Key2,
+	"3":             Key3,
+	"4":             Key4,
+	"5":             Key5,
+	"6":             Key6,
+	"7":             Key7,
+	"8":             Key8,
+	"9":             Key9,
+	"SEMICOLON":     KeySemicolon,
+	"EQUAL":         KeyEqual,
+	"A":             KeyA,
+	"B":             KeyB,
+	"C":             KeyC,
+	"D":             KeyD,
+	"E":             KeyE,
+	"F":             KeyF,
+	"G":             KeyG,
+	"H":             KeyH,
+	"I":             KeyI,
+	"J":             KeyJ,
+	"K":             KeyK,
+	"L":             KeyL,
+	"M":             KeyM,
+	"N":             KeyN,
+	"O":             KeyO,
+	"P":             KeyP,
+	"Q":             KeyQ,
+	"R":             KeyR,
+	"S":             KeyS,
+	"T":             KeyT,
+	"U":             KeyU,
+	"V":             KeyV,
+	"W":             KeyW,
+	"X":             KeyX,
+	"Y":             KeyY,
+	"Z":             KeyZ,
+	"LEFT_BRACKET":  KeyLeftBracket,
+	"BACKSLASH":     KeyBackslash,
+	"RIGHT_BRACKET": KeyRightBracket,
+	"GRAVE_ACCENT":  KeyGraveAccent,
+	"WORLD_1":       KeyWorld1,
+	"WORLD_2":       KeyWorld2,
+	"ESCAPE":        KeyEscape,
+	"ENTER":         KeyEnter,
+	"TAB":           KeyTab,
+	"BACKSPACE":     KeyBackspace,
+	"INSERT":        KeyInsert,
+	"DELETE":        KeyDelete,
+	"RIGHT":         KeyRight,
+	"LEFT":          KeyLeft,
+	"DOWN":          KeyDown,
+	"UP":            KeyUp,
+	"PAGE_UP":       KeyPageUp,
+	"PAGE_DOWN":     KeyPageDown,
+	"HOME":          KeyHome,
+	"END":           KeyEnd,
+	"CAPS_LOCK":     KeyCapsLock,
+	"SCROLL_LOCK":   KeyScrollLock,
+	"NUM_LOCK":      KeyNumLock,
+	"PRINT_SCREEN":  KeyPrintScreen,
+	"PAUSE":         KeyPause,
+	"F1":            KeyF1,
+	"F2":            KeyF2,
+	"F3":            KeyF3,
+	"F4":            KeyF4,
+	"F5":            KeyF5,
+	"F6":            KeyF6,
+	"F7":            KeyF7,
+	"F8":            KeyF8,
+	"F9":            KeyF9,
+	"F10":           KeyF10,
+	"F11":           KeyF11,
+	"F12":           KeyF12,
+	"F13":           KeyF13,
+	"F14":           KeyF14,
+	"F15":           KeyF15,
+	"F16":           KeyF16,
+	"F17":           KeyF17,
+	"F18":           KeyF18,
+	"F19":           KeyF19,
+	"F20":           KeyF20,
+	"F21":           KeyF21,
+	"F22":           KeyF22,
+	"F23":           KeyF23,
+	"F24":           KeyF24,
+	"F25":           KeyF25,
+	"KP_0":          KeyKP0,
+	"KP_1":          KeyKP1,
+	"KP_2":          KeyKP2,
+	"KP_3":          KeyKP3,
+	"KP_4":          KeyKP4,
+	"KP_5":          KeyKP5,
+	"KP_6":          KeyKP6,
+	"KP_7":          KeyKP7,
+	"KP_8":          KeyKP8,
+	"KP_9":          KeyKP9,
+	"KP_DECIMAL":    KeyKPDecimal,
+	"KP_DIVIDE":     KeyKPDivide,
+	"KP_MULTIPLY":   KeyKPMultiply,
+	"KP_SUBTRACT":   KeyKPSubtract,
+	"KP_ADD":        KeyKPAdd,
+	"KP_ENTER":      KeyKPEnter,
+	"KP_EQUAL":      KeyKPEqual,
+	"LEFT_SHIFT":    KeyLeftShift,
+	"LEFT_CONTROL":  KeyLeftControl,
+	"LEFT_ALT":      KeyLeftAlt,
+	"LEFT_SUPER":    KeyLeftSuper,
+	"RIGHT_SHIFT":   KeyRightShift,
+	"RIGHT_CONTROL": KeyRightControl,
+	"RIGHT_ALT":     KeyRightAlt,
+	"RIGHT_SUPER":   KeyRightSuper,
+	"MENU":          KeyMenu,
+}
@@ -0,0 +1,186 @@
+package input
+
+import (
+	"github.com/go-gl/glfw/v3.2/glfw"
+)
+
+// MouseButton : A mouse button
+type MouseButton = glfw.MouseButton
+
+const (
+	MouseButtonLeft   = glfw.MouseButtonLeft
+	MouseButtonRight  = glfw.MouseButtonRight
+	MouseButtonMiddle = glfw.MouseButtonMiddle
+)
+
+// MouseButtonEvent : A mouse button being pressed or released
+type MouseButtonEvent struct {
+	Button MouseButton
+	Action Action
+	Mods   ModifierKey
+}
+
+// CursorEvent : The cursor moving to a position in window pixels, with
+// the origin at the top left of the window
+type CursorEvent struct {
+	X float64
+	Y float64
+}
+
+// ScrollEvent : The scroll wheel or trackpad being scrolled
+type ScrollEvent struct {
+	X float64
+	Y float64
+}
+
+// MouseState : Stores the cursor position, button state, scroll offset
+// and mouse callbacks
+type MouseState struct {
+	Cursor               CursorEvent
+	Down                 map[MouseButton]bool
+	Pressed              map[MouseButton]bool
+	Released             map[MouseButton]bool
+	Scroll               ScrollEvent
+	MouseButtonCallbacks []func(MouseButtonEvent)
+	CursorCallbacks      []func(CursorEvent)
+	ScrollCallbacks      []func(ScrollEvent)
+}
+
+var mouseState = newMouseState()
+
+func newMouseState() *MouseState {
+	return &MouseState{
+		Down:     map[MouseButton]bool{},
+		Pressed:  map[MouseButton]bool{},
+		Released: map[MouseButton]bool{},
+	}
+}
+
+func updateMouse() {
+	mouseState.Pressed = map[MouseButton]bool{}
+	mouseState.Released = map[MouseButton]bool{}
+	mouseState.Scroll = ScrollEvent{}
+}
+
+func mouseButtonCallback(
+	window *glfw.Window,
+	button glfw.MouseButton,
+	action glfw.Action,
+	mods glfw.ModifierKey,
+) {
+	if !live() {
+		return
+	}
+
+	HandleMouseButton(MouseButtonEvent{
+		Button: button,
+		Action: action,
+		Mods:   mods,
+	})
+}
+
+func cursorPosCallback(window *glfw.Window, x float64, y float64) {
+	if !live() {
+		return
+	}
+
+	HandleCursor(CursorEvent{X: x, Y: y})
+}
+
+func scrollCallback(window *glfw.Window, x float64, y float64) {
+	if !live() {
+		return
+	}
+
+	HandleScroll(ScrollEvent{X: x, Y: y})
+}
+
+// HandleMouseButton : Updates the button state from the event and calls the
+// mouse button callbacks
+func HandleMouseButton(event MouseButtonEvent) {
+	record(Event{Type: EventMouseButton, MouseButton: event})
+
+	switch event.Action {
+	case Press:
+		if !mouseState.Down[event.Button] {
+			mouseState.Pressed[event.Button] = true
+		}
+		mouseState.Down[event.Button] = true
+	case Release:
+		if mouseState.Down[event.Button] {
+			mouseState.Released[event.Button] = true
+		}
+		delete(mouseState.Down, event.Button)
+	}
+
+	for _, callback := range mouseState.MouseButtonCallbacks {
+		callback(event)
+	}
+}
+
+// HandleCursor : Updates the cursor position and calls the cursor callbacks
+func HandleCursor(event CursorEvent) {
+	record(Event{Type: EventCursor, Cursor: event})
+
+	mouseState.Cursor = event
+
+	for _, callback := range mouseState.CursorCallbacks {
+		callback(event)
+	}
+}
+
+// HandleScroll : Adds to the scroll offset for this frame and calls the
+// scroll callbacks
+func HandleScroll(event ScrollEvent) {
+	record(Event{Type: EventScroll, Scroll: event})
+
+	mouseState.Scroll.X += event.X
+	mouseState.Scroll.Y += event.Y
+
+	for _, callback := range mouseState.ScrollCallbacks {
+		callback(event)
+	}
+}
+
+// OnMouseButton : Registers a function to be called with every mouse
+// button event
+func OnMouseButton(callback func(MouseButtonEvent)) {
+	mouseState.MouseButtonCallbacks = append(mouseState.MouseButtonCallbacks, callback)
+}
+
+// OnCursor : Registers a function to be called whenever the cursor moves
+func OnCursor(callback func(CursorEvent)) {
+	mouseState.CursorCallbacks = append(mouseState.CursorCallbacks, callback)
+}
+
+// OnScroll : Registers a function to be called with every scroll event
+func OnScroll(callback func(ScrollEvent)) {
+	mouseState.ScrollCallbacks = append(mouseState.ScrollCallbacks, callback)
+}
+
+// CursorPosition : Returns the cursor position in window pixels, with the
+// origin at the top left of the window
+func CursorPosition() (float64, float64) {
+	return mouseState.Cursor.X, mouseState.Cursor.Y
+}
+
+// MouseDown : Returns true if the button is currently held down
+func MouseDown(button MouseButton) bool {
+	return mouseState.Down[button]
+}
+
+// MouseJustPressed : Returns true if the button was pressed since the last frame
+func MouseJustPressed(button MouseButton) bool {
+	return mouseState.Pressed[button]
+}
+
+// MouseJustReleased : Returns true if the button was released since the
+// last frame
+func MouseJustReleased(button MouseButton) bool {
+	return mouseState.Released[button]
+}
+
+// Scroll : Returns the total scroll offset since the last frame
+func Scroll() (float64, float64) {
+	return mouseState.Scroll.X, mouseState.Scroll.Y
+}
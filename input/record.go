@@ -0,0 +1,338 @@
+package input
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/leedenison/gologo/time"
+)
+
+// EventType : The kind of input event
+type EventType uint8
+
+const (
+	EventKey EventType = iota + 1
+	EventMouseButton
+	EventCursor
+	EventScroll
+)
+
+// Event : An input event of any type together with the tick time, relative
+// to the start of the recording, at which it was received.  Only the field
+// matching Type is set.
+type Event struct {
+	Tick        int
+	Type        EventType
+	Key         KeyEvent
+	MouseButton MouseButtonEvent
+	Cursor      CursorEvent
+	Scroll      ScrollEvent
+}
+
+// Recording : A sequence of input events in the order they were received
+type Recording struct {
+	Events []Event
+
+	start int
+}
+
+// Player : Replays a recording, dispatching each event once the tick time
+// since the replay started reaches the tick at which it was recorded
+type Player struct {
+	Recording *Recording
+
+	start int
+	next  int
+}
+
+var recording *Recording
+var player *Player
+
+// recordingMagic : Identifies a recording file and its format version
+var recordingMagic = [4]byte{'G', 'L', 'I', '1'}
+
+/////////////////////////////////////////////////////////////
+// Dispatch
+//
+
+// Dispatch : Handles the event as if it had been received from the window
+func Dispatch(event Event) {
+	switch event.Type {
+	case EventKey:
+		HandleKey(event.Key)
+	case EventMouseButton:
+		HandleMouseButton(event.MouseButton)
+	case EventCursor:
+		HandleCursor(event.Cursor)
+	case EventScroll:
+		HandleScroll(event.Scroll)
+	}
+}
+
+// InjectKey : Handles a synthetic key event
+func InjectKey(key Key, action Action) {
+	HandleKey(KeyEvent{Key: key, Action: action})
+}
+
+// InjectMouseButton : Handles a synthetic mouse button event
+func InjectMouseButton(button MouseButton, action Action) {
+	HandleMouseButton(MouseButtonEvent{Button: button, Action: action})
+}
+
+// InjectCursor : Handles a synthetic cursor movement to the window position
+func InjectCursor(x float64, y float64) {
+	HandleCursor(CursorEvent{X: x, Y: y})
+}
+
+// InjectScroll : Handles a synthetic scroll event
+func InjectScroll(x float64, y float64) {
+	HandleScroll(ScrollEvent{X: x, Y: y})
+}
+
+// live : Returns true if events from the window should be handled.  Window
+// events are ignored while a recording is being replayed.
+func live() bool {
+	return player == nil || player.Done()
+}
+
+func record(event Event) {
+	if recording == nil {
+		return
+	}
+
+	event.Tick = time.GetTickTime() - recording.start
+	recording.Events = append(recording.Events, event)
+}
+
+/////////////////////////////////////////////////////////////
+// Recording
+//
+
+// StartRecording : Starts recording every input event handled, including
+// injected events, with the tick time relative to now
+func StartRecording() *Recording {
+	recording = &Recording{
+		Events: []Event{},
+		start:  time.GetTickTime(),
+	}
+	return recording
+}
+
+// StopRecording : Stops recording and returns the recording
+func StopRecording() *Recording {
+	result := recording
+	recording = nil
+	return result
+}
+
+// Save : Writes the recording to a file
+func (r *Recording) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to save recording %q: %v", path, err)
+	}
+
+	if err := r.Write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to save recording %q: %v", path, err)
+	}
+
+	return file.Close()
+}
+
+// Write : Writes the recording in a compact binary format.  Ticks are
+// stored as deltas from the previous event.
+func (r *Recording) Write(w io.Writer) error {
+	out := bufio.NewWriter(w)
+	out.Write(recordingMagic[:])
+
+	buf := make([]byte, binary.MaxVarintLen64)
+	putInt := func(v int) {
+		out.Write(buf[:binary.PutVarint(buf, int64(v))])
+	}
+	putFloat := func(v float64) {
+		binary.LittleEndian.PutUint64(buf, math.Float64bits(v))
+		out.Write(buf[:8])
+	}
+
+	putInt(len(r.Events))
+
+	tick := 0
+	for _, event := range r.Events {
+		putInt(event.Tick - tick)
+		tick = event.Tick
+		out.WriteByte(byte(event.Type))
+
+		switch event.Type {
+		case EventKey:
+			putInt(int(event.Key.Key))
+			putInt(event.Key.Scancode)
+			putInt(int(event.Key.Action))
+			putInt(int(event.Key.Mods))
+		case EventMouseButton:
+			putInt(int(event.MouseButton.Button))
+			putInt(int(event.MouseButton.Action))
+			putInt(int(event.MouseButton.Mods))
+		case EventCursor:
+			putFloat(event.Cursor.X)
+			putFloat(event.Cursor.Y)
+		case EventScroll:
+			putFloat(event.Scroll.X)
+			putFloat(event.Scroll.Y)
+		default:
+			return fmt.Errorf("unknown event type: %v", event.Type)
+		}
+	}
+
+	return out.Flush()
+}
+
+// LoadRecording : Reads a recording from a file written by Save
+func LoadRecording(path string) (*Recording, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load recording %q: %v", path, err)
+	}
+	defer file.Close()
+
+	result, err := ReadRecording(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load recording %q: %v", path, err)
+	}
+
+	return result, nil
+}
+
+// ReadRecording : Reads a recording in the format written by Write
+func ReadRecording(r io.Reader) (*Recording, error) {
+	in := bufio.NewReader(r)
+
+	var magic [4]byte
+	if _, err := io.ReadFull(in, magic[:]); err != nil {
+		return nil, err
+	}
+	if magic != recordingMagic {
+		return nil, errors.New("not a recording")
+	}
+
+	var err error
+	getInt := func() int {
+		if err != nil {
+			return 0
+		}
+		var v int64
+		v, err = binary.ReadVarint(in)
+		return int(v)
+	}
+	getFloat := func() float64 {
+		if err != nil {
+			return 0
+		}
+		var buf [8]byte
+		_, err = io.ReadFull(in, buf[:])
+		return math.Float64frombits(binary.LittleEndian.Uint64(buf[:]))
+	}
+
+	count := getInt()
+	if err != nil {
+		return nil, err
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("invalid event count %v", count)
+	}
+
+	// The count is not trusted to preallocate, as a corrupt recording
+	// would otherwise allocate without bound
+	result := &Recording{}
+
+	tick := 0
+	for i := 0; i < count; i++ {
+		tick += getInt()
+		event := Event{Tick: tick}
+
+		var kind byte
+		if err == nil {
+			kind, err = in.ReadByte()
+		}
+		event.Type = EventType(kind)
+
+		switch event.Type {
+		case EventKey:
+			event.Key.Key = Key(getInt())
+			event.Key.Scancode = getInt()
+			event.Key.Action = Action(getInt())
+			event.Key.Mods = ModifierKey(getInt())
+		case EventMouseButton:
+			event.MouseButton.Button = MouseButton(getInt())
+			event.MouseButton.Action = Action(getInt())
+			event.MouseButton.Mods = ModifierKey(getInt())
+		case EventCursor:
+			event.Cursor.X = getFloat()
+			event.Cursor.Y = getFloat()
+		case EventScroll:
+			event.Scroll.X = getFloat()
+			event.Scroll.Y = getFloat()
+		default:
+			if err == nil {
+				err = fmt.Errorf("unknown event type: %v", event.Type)
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		result.Events = append(result.Events, event)
+	}
+
+	return result, nil
+}
+
+/////////////////////////////////////////////////////////////
+// Replay
+//
+
+// Replay : Starts replaying the recording from the current tick time.
+// Events are dispatched by Update, once per frame, and events from the
+// window are ignored until the replay is done or stopped.
+func Replay(r *Recording) *Player {
+	player = &Player{
+		Recording: r,
+		start:     time.GetTickTime(),
+	}
+	return player
+}
+
+// Done : Returns true once every event in the recording has been dispatched
+func (p *Player) Done() bool {
+	return p.next >= len(p.Recording.Events)
+}
+
+// Stop : Stops the replay, leaving any remaining events undispatched
+func (p *Player) Stop() {
+	p.next = len(p.Recording.Events)
+	if player == p {
+		player = nil
+	}
+}
+
+// update : Dispatches every event recorded at or before the tick time
+// since the replay started
+func (p *Player) update() {
+	elapsed := time.GetTickTime() - p.start
+
+	for !p.Done() && p.Recording.Events[p.next].Tick <= elapsed {
+		event := p.Recording.Events[p.next]
+		p.next++
+		Dispatch(event)
+	}
+
+	if p.Done() && player == p {
+		player = nil
+	}
+}
@@ -0,0 +1,109 @@
+package input
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/leedenison/gologo/time"
+)
+
+var clock = &time.ManualClock{}
+
+// setTick : Sets the tick time in milliseconds
+func setTick(ms int) {
+	clock.Time = float64(ms) / 1000
+	time.Tick()
+}
+
+func TestRecordReplay(t *testing.T) {
+	Reset()
+	time.SetClock(clock)
+	setTick(1000)
+
+	StartRecording()
+	InjectKey(KeySpace, Press)
+	setTick(1016)
+	InjectCursor(12.5, 40)
+	InjectMouseButton(MouseButtonLeft, Press)
+	setTick(1050)
+	InjectScroll(0, -1)
+	InjectKey(KeySpace, Release)
+	recorded := StopRecording()
+
+	ticks := []int{}
+	for _, event := range recorded.Events {
+		ticks = append(ticks, event.Tick)
+	}
+	if !reflect.DeepEqual(ticks, []int{0, 16, 16, 50, 50}) {
+		t.Errorf("Ticks was (%v) should be (%v)", ticks, []int{0, 16, 16, 50, 50})
+	}
+
+	path := filepath.Join(t.TempDir(), "input.rec")
+	if err := recorded.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := LoadRecording(path)
+	if err != nil {
+		t.Fatalf("LoadRecording failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Events, recorded.Events) {
+		t.Errorf("Loaded events was (%v) should be (%v)", loaded.Events, recorded.Events)
+	}
+
+	Reset()
+	setTick(5000)
+	p := Replay(loaded)
+
+	var replayTests = []struct {
+		tick      int
+		spaceDown bool
+		cursorX   float64
+	}{
+		{5000, true, 0},
+		{5010, true, 0},
+		{5016, true, 12.5},
+		{5050, false, 12.5},
+	}
+
+	for _, test := range replayTests {
+		setTick(test.tick)
+		Update()
+
+		if IsDown(KeySpace) != test.spaceDown {
+			t.Errorf("At %v space down was (%v) should be (%v)",
+				test.tick, IsDown(KeySpace), test.spaceDown)
+		}
+		if x, _ := CursorPosition(); x != test.cursorX {
+			t.Errorf("At %v cursor x was (%v) should be (%v)", test.tick, x, test.cursorX)
+		}
+	}
+
+	if !p.Done() {
+		t.Errorf("Replay should be done after the last event")
+	}
+}
+
+func TestReadRecordingInvalid(t *testing.T) {
+	if _, err := ReadRecording(bytes.NewReader([]byte("nope"))); err == nil {
+		t.Errorf("ReadRecording should fail for data without the header")
+	}
+
+	var buf bytes.Buffer
+	(&Recording{Events: []Event{{Type: EventKey}}}).Write(&buf)
+	truncated := buf.Bytes()[:buf.Len()-1]
+	if _, err := ReadRecording(bytes.NewReader(truncated)); err == nil {
+		t.Errorf("ReadRecording should fail for truncated data")
+	}
+
+	for _, count := range []int64{-1, math.MinInt64, math.MaxInt64} {
+		header := append([]byte{}, recordingMagic[:]...)
+		header = binary.AppendVarint(header, count)
+		if _, err := ReadRecording(bytes.NewReader(header)); err == nil {
+			t.Errorf("ReadRecording should fail for event count (%v)", count)
+		}
+	}
+}
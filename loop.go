@@ -0,0 +1,109 @@
+package gologo
+
+import (
+	"github.com/leedenison/gologo/input"
+	"github.com/leedenison/gologo/time"
+)
+
+const (
+	defaultUpdateRate = 60
+	// maxFrameInterval : Limits the simulation time consumed in a single
+	// frame so that a long pause does not cause a burst of updates
+	maxFrameInterval = 0.25
+	// stepTolerance : Allows for rounding error in the accumulated frame
+	// intervals, so that a clock stepped by exactly one update interval
+	// makes exactly one update
+	stepTolerance = 1e-9
+)
+
+// Add : Adds objects to the current scene to be drawn by Run
+func (g *Gologo) Add(objects ...*Object) {
+	g.scene.Add(objects...)
+}
+
+// Remove : Removes an object from the current scene so that it is no
+// longer drawn by Run
+func (g *Gologo) Remove(object *Object) {
+	g.scene.Remove(object)
+}
+
+// Objects : Returns the objects in the current scene in z-order
+func (g *Gologo) Objects() []*Object {
+	return g.scene.Objects()
+}
+
+// OnUpdate : Registers a function to be called at the fixed update rate
+// with the simulated time step in seconds, regardless of the current scene
+func (g *Gologo) OnUpdate(update func(dt float64)) {
+	g.updates = append(g.updates, update)
+}
+
+// OnTick : Registers a function to be called once per frame with the
+// current tick time in milliseconds
+func (g *Gologo) OnTick(callback func(tick int)) {
+	g.tickCallbacks = append(g.tickCallbacks, callback)
+}
+
+// Run : Runs the game loop until the window is closed.  update, if not
+// nil, is registered with OnUpdate before the loop starts.  See Step.
+func (g *Gologo) Run(update func(dt float64)) {
+	if update != nil {
+		g.OnUpdate(update)
+	}
+
+	for !g.ShouldClose() {
+		g.Step()
+	}
+}
+
+// Step : Runs a single frame of the game loop.  The tick time is advanced
+// and tick callbacks called, then update functions and scripts are run as
+// many times as needed to catch up at the fixed update rate.  Objects in the current
+// scene are drawn in z-order, interpolated between their previous and current state by
+// the fraction of an update remaining, before the buffers are swapped and
+// events polled.  The keys and buttons just pressed or released are
+// cleared after each update rather than each frame, so that every update
+// function sees each press exactly once however many frames or updates
+// there are.
+func (g *Gologo) Step() {
+	time.Tick()
+
+	tick := time.GetTickTime()
+	for _, callback := range g.tickCallbacks {
+		callback(tick)
+	}
+
+	interval := time.TimeState.Interval
+	if interval > maxFrameInterval {
+		interval = maxFrameInterval
+	}
+	g.accumulator += interval
+
+	dt := 1.0 / float64(g.updateRate)
+	for g.accumulator >= dt-stepTolerance {
+		g.scene.saveState()
+		for _, update := range g.updates {
+			update(dt)
+		}
+		if g.scene.OnUpdate != nil {
+			g.scene.OnUpdate(dt)
+		}
+		g.runScripts(dt)
+		input.ClearPressed()
+		g.accumulator -= dt
+	}
+	if g.accumulator < 0 {
+		g.accumulator = 0
+	}
+
+	g.ClearBackBuffer()
+	g.Draw(g.accumulator / dt)
+	g.SwapBuffers()
+	g.pollEvents()
+}
+
+// Draw : Draws the objects in the current scene in z-order, interpolated
+// by alpha between their state before the last update and their current state
+func (g *Gologo) Draw(alpha float64) {
+	g.scene.DrawInterpolated(alpha)
+}
@@ -0,0 +1,167 @@
+package gologo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo/input"
+	"github.com/leedenison/gologo/time"
+)
+
+// TestRunFixedUpdates : Test that Run calls update at the fixed rate
+// and tick callbacks every frame until the window is closed
+func TestRunFixedUpdates(t *testing.T) {
+	g := InitWithConfig(Config{Width: 64, Height: 64, Headless: true, UpdateRate: 100})
+	defer g.Close()
+
+	ticks := 0
+	g.OnTick(func(tick int) {
+		ticks++
+	})
+
+	updates := 0
+	g.Run(func(dt float64) {
+		if math.Abs(dt-0.01) > epsilon {
+			t.Errorf("dt was (%v) should be (0.01)", dt)
+		}
+		updates++
+		if updates == 3 {
+			g.SetShouldClose(true)
+		}
+	})
+
+	if updates != 3 {
+		t.Errorf("Updates was (%v) should be (3)", updates)
+	}
+	if ticks < 1 {
+		t.Errorf("Ticks was (%v) should be at least (1)", ticks)
+	}
+}
+
+var interpolateTests = []struct {
+	name                      string
+	fromX, toX                float32
+	fromAngle, toAngle, alpha float64
+	expX                      float32
+	expAngle                  float64
+}{
+	{"start", 0, 100, 0, 1, 0, 0, 0},
+	{"halfway", 0, 100, 0, 1, 0.5, 50, 0.5},
+	{"end", 0, 100, 0, 1, 1, 100, 1},
+	{"across zero angle", 0, 0, 2*math.Pi - 0.2, 0.2, 0.5, 0, 2 * math.Pi},
+}
+
+// TestGetInterpolatedModel : Test that the model is interpolated between
+// the state saved before an update and the current state
+func TestGetInterpolatedModel(t *testing.T) {
+	for _, tc := range interpolateTests {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := CreateObject(mgl32.Vec3{tc.fromX, 0, 0})
+			obj.Scale = 1.0
+			obj.Orientation = tc.fromAngle
+			obj.saveState()
+
+			obj.Position = mgl32.Vec3{tc.toX, 0, 0}
+			obj.Orientation = tc.toAngle
+
+			model := obj.GetInterpolatedModel(tc.alpha)
+			expected := modelMatrix(mgl32.Vec3{tc.expX, 0, 0}, tc.expAngle, 1.0)
+			if !model.ApproxEqualThreshold(expected, epsilon) {
+				t.Errorf("Model was %v should be %v", model, expected)
+			}
+		})
+	}
+}
+
+// TestInterpolatedModelUnsavedParent : Test that a parent which is not in
+// a scene, and so has never saved its state, is drawn at its current state
+func TestInterpolatedModelUnsavedParent(t *testing.T) {
+	pivot := CreateObject(mgl32.Vec3{10, 20, 0})
+	pivot.Scale = 2.0
+	child := CreateObject(mgl32.Vec3{5, 0, 0})
+	child.Scale = 1.0
+	pivot.AddChild(child)
+
+	scene := NewScene()
+	scene.Add(child)
+
+	model := child.GetInterpolatedModel(0.5)
+	if expected := child.GetModel(); !model.ApproxEqualThreshold(expected, epsilon) {
+		t.Errorf("Model was %v should be %v", model, expected)
+	}
+}
+
+// TestStepManualClock : Test that a manual clock makes the number of
+// fixed updates per step deterministic, and that none are made while paused
+func TestStepManualClock(t *testing.T) {
+	clock := &time.ManualClock{}
+	g := InitWithConfig(Config{Width: 64, Height: 64, Headless: true, UpdateRate: 100, Clock: clock})
+	defer g.Close()
+	defer time.Resume()
+
+	updates := 0
+	g.OnUpdate(func(dt float64) {
+		updates++
+	})
+
+	var stepTests = []struct {
+		name    string
+		action  func()
+		updates int
+	}{
+		{"one update", func() { clock.Step(10) }, 1},
+		{"three updates", func() { clock.Step(30) }, 4},
+		{"paused", func() { time.Pause(); clock.Step(30) }, 4},
+		{"single step", func() { time.Step(10) }, 5},
+	}
+
+	for _, test := range stepTests {
+		t.Run(test.name, func(t *testing.T) {
+			test.action()
+			g.Step()
+			if updates != test.updates {
+				t.Errorf("Updates was (%v) should be (%v)", updates, test.updates)
+			}
+		})
+	}
+}
+
+// TestStepJustPressed : Test that each key press is seen by exactly one
+// fixed update, whether frames are more or less frequent than updates
+func TestStepJustPressed(t *testing.T) {
+	var stepTests = []struct {
+		name      string
+		frameStep int
+		frames    int
+	}{
+		{"more frames than updates", 3, 12},
+		{"more updates than frames", 25, 4},
+	}
+
+	for _, test := range stepTests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := &time.ManualClock{}
+			g := InitWithConfig(Config{Width: 64, Height: 64, Headless: true, UpdateRate: 100, Clock: clock})
+			defer g.Close()
+			defer input.Reset()
+
+			seen := 0
+			g.OnUpdate(func(dt float64) {
+				if input.JustPressed(input.KeySpace) {
+					seen++
+				}
+			})
+
+			input.HandleKey(input.KeyEvent{Key: input.KeySpace, Action: input.Press})
+			for i := 0; i < test.frames; i++ {
+				clock.Step(test.frameStep)
+				g.Step()
+			}
+
+			if seen != 1 {
+				t.Errorf("Press was seen by (%v) updates should be (1)", seen)
+			}
+		})
+	}
+}
@@ -45,3 +45,15 @@ func getRectMinMax(rect Rect) (float32, float32, float32, float32) {
 
 	return xMin, xMax, yMin, yMax
 }
+
+// shortestAngle : Returns the signed angle in radians of the smallest
+// rotation from angle a to angle b
+func shortestAngle(a float64, b float64) float64 {
+	d := math.Mod(b-a, 2*math.Pi)
+	if d > math.Pi {
+		d -= 2 * math.Pi
+	} else if d < -math.Pi {
+		d += 2 * math.Pi
+	}
+	return d
+}
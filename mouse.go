@@ -0,0 +1,50 @@
+package gologo
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo/input"
+	"github.com/leedenison/gologo/render"
+)
+
+// WindowToWorld : Returns the world space point under the window position
+// in pixels.  Window positions have their origin at the top left with Y
+// increasing downwards, whereas the world Y axis increases upwards.
+func (g *Gologo) WindowToWorld(x float64, y float64) mgl32.Vec2 {
+	width, height := g.GetWindowSize()
+	if width == 0 || height == 0 {
+		return mgl32.Vec2{}
+	}
+
+	ndc := mgl32.Vec4{
+		float32(2*x/float64(width) - 1),
+		float32(1 - 2*y/float64(height)),
+		0,
+		1,
+	}
+
+	return render.GetProjection().Inv().Mul4x1(ndc).Vec2()
+}
+
+// CursorWorldPosition : Returns the world space point under the cursor
+func (g *Gologo) CursorWorldPosition() mgl32.Vec2 {
+	return g.WindowToWorld(input.CursorPosition())
+}
+
+// ObjectUnderCursor : Returns the top-most object in the current scene
+// under the cursor, or nil.  For example, to drag objects with the mouse:
+//
+//	if input.MouseJustPressed(input.MouseButtonLeft) {
+//		dragging = g.ObjectUnderCursor()
+//	}
+//	if input.MouseJustReleased(input.MouseButtonLeft) {
+//		dragging = nil
+//	}
+//	if dragging != nil {
+//		dragging.SetWorldPosition(g.CursorWorldPosition().Vec3(0))
+//	}
+func (g *Gologo) ObjectUnderCursor() *Object {
+	if g.scene == nil {
+		return nil
+	}
+	return g.scene.Pick(g.CursorWorldPosition())
+}
@@ -0,0 +1,129 @@
+package gologo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo/input"
+	"github.com/leedenison/gologo/render"
+)
+
+// square : Creates an object drawn as a square of the given size centered
+// on its origin
+func square(position mgl32.Vec3, size float32) *Object {
+	h := size / 2
+	o := CreateObject(position)
+	o.Scale = 1.0
+	o.Renderer = &render.MeshRenderer{
+		MeshVertices: []float32{
+			-h, -h, 0, 0, 0,
+			h, -h, 0, 1, 0,
+			h, h, 0, 1, 1,
+			-h, -h, 0, 0, 0,
+			h, h, 0, 1, 1,
+			-h, h, 0, 0, 1,
+		},
+	}
+	return o
+}
+
+var windowToWorldTests = []struct {
+	name  string
+	x, y  float64
+	world mgl32.Vec2
+}{
+	{"top left", 0, 0, mgl32.Vec2{0, 100}},
+	{"bottom left", 0, 100, mgl32.Vec2{0, 0}},
+	{"center", 100, 50, mgl32.Vec2{100, 50}},
+}
+
+// TestWindowToWorld : Test that window pixels are converted to world
+// space with the Y axis flipped
+func TestWindowToWorld(t *testing.T) {
+	g := InitWithConfig(Config{Width: 200, Height: 100, Headless: true})
+	defer g.Close()
+
+	for _, test := range windowToWorldTests {
+		t.Run(test.name, func(t *testing.T) {
+			world := g.WindowToWorld(test.x, test.y)
+			if !world.ApproxEqualThreshold(test.world, epsilon) {
+				t.Errorf("World was (%v) should be (%v)", world, test.world)
+			}
+		})
+	}
+
+	input.Reset()
+	input.HandleCursor(input.CursorEvent{X: 50, Y: 25})
+	world := g.CursorWorldPosition()
+	if !world.ApproxEqualThreshold(mgl32.Vec2{50, 75}, epsilon) {
+		t.Errorf("Cursor world position was (%v) should be (%v)", world, mgl32.Vec2{50, 75})
+	}
+}
+
+var containsPointTests = []struct {
+	name        string
+	orientation float64
+	scale       float64
+	point       mgl32.Vec2
+	contains    bool
+}{
+	{"inside", 0, 1, mgl32.Vec2{104, 104}, true},
+	{"outside", 0, 1, mgl32.Vec2{106, 100}, false},
+	{"rotated corner", math.Pi / 4, 1, mgl32.Vec2{104, 104}, false},
+	{"rotated point", math.Pi / 4, 1, mgl32.Vec2{106, 100}, true},
+	{"scaled", 0, 2, mgl32.Vec2{109, 100}, true},
+}
+
+// TestContainsPoint : Test that hit-testing respects the rotation and
+// scale of the object
+func TestContainsPoint(t *testing.T) {
+	for _, test := range containsPointTests {
+		t.Run(test.name, func(t *testing.T) {
+			o := square(mgl32.Vec3{100, 100, 0}, 10)
+			o.Orientation = test.orientation
+			o.Scale = test.scale
+
+			if got := o.ContainsPoint(test.point); got != test.contains {
+				t.Errorf("ContainsPoint was (%v) should be (%v)", got, test.contains)
+			}
+		})
+	}
+}
+
+// TestContainsPointDegenerate : Test that meshes of triangles with no
+// area, such as circles of radius zero, contain no points
+func TestContainsPointDegenerate(t *testing.T) {
+	o := square(mgl32.Vec3{100, 100, 0}, 0)
+
+	for _, point := range []mgl32.Vec2{{100, 100}, {0, 0}, {150, 120}} {
+		if o.ContainsPoint(point) {
+			t.Errorf("ContainsPoint(%v) was (true) should be (false)", point)
+		}
+	}
+}
+
+// TestScenePick : Test that the top-most object under the point is picked
+func TestScenePick(t *testing.T) {
+	scene := NewScene()
+
+	below := square(mgl32.Vec3{100, 100, 0}, 20)
+	above := square(mgl32.Vec3{110, 100, 0}, 20)
+	above.SetZOrder(1)
+	scene.Add(above, below)
+
+	var pickTests = []struct {
+		point mgl32.Vec2
+		want  *Object
+	}{
+		{mgl32.Vec2{95, 100}, below},
+		{mgl32.Vec2{105, 100}, above},
+		{mgl32.Vec2{200, 200}, nil},
+	}
+
+	for _, test := range pickTests {
+		if got := scene.Pick(test.point); got != test.want {
+			t.Errorf("Pick(%v) was (%v) should be (%v)", test.point, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package obj
+
+import (
+	"fmt"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+	"github.com/leedenison/gologo/render"
+	"github.com/leedenison/gologo/time"
+)
+
+// AnimatedSprite : Returns an object centered on the origin playing the
+// named animation from the sprite sheet.  Sprite functions such as
+// SetFlip and SetTint also apply to animated sprites.
+func AnimatedSprite(origin mgl32.Vec2, sheet *render.SpriteSheet, animation string) *gologo.Object {
+	animationRenderer, err := render.NewAnimationRenderer(sheet, animation)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to create AnimatedSprite renderer: %v\n", err))
+	}
+
+	return &gologo.Object{
+		Position: mgl32.Vec3{origin[0], origin[1], 0.0},
+		Scale:    1.0,
+		Creation: time.GetTickTime(),
+		ZOrder:   0,
+		Renderer: animationRenderer,
+	}
+}
+
+// Play : Starts the named animation of an animated sprite from its first
+// frame
+func Play(object *gologo.Object, animation string) {
+	if err := animationRenderer(object, "play").Play(animation); err != nil {
+		panic(fmt.Sprintf("Failed to play animation: %v\n", err))
+	}
+}
+
+// OnAnimationFinish : Registers a function called with the name of the
+// animation when an animated sprite finishes an animation played once, or
+// completes a loop of a repeating animation
+func OnAnimationFinish(object *gologo.Object, callback func(name string)) {
+	animationRenderer(object, "watch").OnFinish = callback
+}
+
+func animationRenderer(object *gologo.Object, action string) *render.AnimationRenderer {
+	animationRenderer, ok := object.Renderer.(*render.AnimationRenderer)
+	if !ok {
+		panic(fmt.Sprintf("Failed to %v animation: unsupported renderer %T\n", action, object.Renderer))
+	}
+	return animationRenderer
+}
@@ -0,0 +1,173 @@
+package obj
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// arcSamples : The number of samples per segment in the arc length table
+const arcSamples = 64
+
+// arcTable : The cumulative length of the path at evenly spaced parameter
+// values along each segment.  lengths[i*(arcSamples+1)+j] is the length of
+// the path up to parameter j/arcSamples of segment i.
+type arcTable struct {
+	lengths []float32
+	total   float32
+}
+
+// Recalculate : Rebuilds the arc length table and segment Times from the
+// Segments.  Call after changing Segments.
+func (p *Path) Recalculate() {
+	table := &arcTable{
+		lengths: make([]float32, 0, len(p.Segments)*(arcSamples+1)),
+	}
+	segmentLengths := make([]float32, len(p.Segments))
+
+	for i, s := range p.Segments {
+		previous := s[0]
+		start := table.total
+		table.lengths = append(table.lengths, table.total)
+
+		for j := 1; j <= arcSamples; j++ {
+			point := mgl32.CubicBezierCurve2D(float32(j)/arcSamples, s[0], s[1], s[2], s[3])
+			table.total += point.Sub(previous).Len()
+			table.lengths = append(table.lengths, table.total)
+			previous = point
+		}
+
+		segmentLengths[i] = table.total - start
+	}
+
+	p.table = table
+	p.Times = make([]float32, len(p.Segments))
+	for i, length := range segmentLengths {
+		if table.total > 0 {
+			p.Times[i] = length / table.total
+		} else {
+			p.Times[i] = 1 / float32(len(p.Segments))
+		}
+	}
+}
+
+func (p *Path) arcLengths() *arcTable {
+	if p.table == nil || len(p.table.lengths) != len(p.Segments)*(arcSamples+1) {
+		p.Recalculate()
+	}
+	return p.table
+}
+
+// Length : Returns the length of the path
+func (p *Path) Length() float32 {
+	return p.arcLengths().total
+}
+
+// locate : Returns the segment and the Bezier parameter within it at the
+// distance along the path, clamped to the ends of the path
+func (p *Path) locate(distance float32) (int, float32) {
+	table := p.arcLengths()
+	if len(p.Segments) == 0 {
+		return -1, 0
+	}
+
+	if distance <= 0 {
+		return 0, 0
+	}
+	if distance >= table.total {
+		return len(p.Segments) - 1, 1
+	}
+
+	// Find the first sample at or beyond the distance, then interpolate
+	// between it and the previous sample
+	k := sort.Search(len(table.lengths), func(k int) bool {
+		return table.lengths[k] >= distance
+	})
+	if k == 0 {
+		return 0, 0
+	}
+
+	segment := k / (arcSamples + 1)
+	j := k % (arcSamples + 1)
+	if j == 0 {
+		// The first sample of a segment repeats the last of the previous
+		return segment, 0
+	}
+
+	before := table.lengths[k-1]
+	after := table.lengths[k]
+	f := float32(0)
+	if after > before {
+		f = (distance - before) / (after - before)
+	}
+
+	return segment, (float32(j-1) + f) / arcSamples
+}
+
+// PositionAtDistance : Returns the point at the distance along the path
+func (p *Path) PositionAtDistance(distance float32) mgl32.Vec2 {
+	i, t := p.locate(distance)
+	if i < 0 {
+		return mgl32.Vec2{}
+	}
+
+	s := p.Segments[i]
+	return mgl32.CubicBezierCurve2D(t, s[0], s[1], s[2], s[3])
+}
+
+// TangentAtDistance : Returns the unit direction of the path at the
+// distance along it
+func (p *Path) TangentAtDistance(distance float32) mgl32.Vec2 {
+	i, t := p.locate(distance)
+	if i < 0 {
+		return mgl32.Vec2{1, 0}
+	}
+
+	tangent := bezierDerivative(p.Segments[i], t)
+	if tangent.Len() < 1e-6 {
+		// Control points coincide with the end points, so approximate
+		// the direction from nearby points
+		const delta = 0.01
+		tangent = p.PositionAtDistance(distance + delta).
+			Sub(p.PositionAtDistance(distance - delta))
+	}
+	if tangent.Len() == 0 {
+		return mgl32.Vec2{1, 0}
+	}
+
+	return tangent.Normalize()
+}
+
+// PositionAt : Returns the point on the path at the time, from 0 at the
+// start to 1 at the end.  Equal changes in time move equal distances along
+// the path.  Times outside 0 to 1 are clamped to the ends of the path.
+func (p *Path) PositionAt(time float32) mgl32.Vec2 {
+	return p.PositionAtDistance(time * p.Length())
+}
+
+// TangentAt : Returns the unit direction of the path at the time
+func (p *Path) TangentAt(time float32) mgl32.Vec2 {
+	return p.TangentAtDistance(time * p.Length())
+}
+
+// NormalAt : Returns the unit normal of the path at the time, pointing to
+// the left of the direction of travel
+func (p *Path) NormalAt(time float32) mgl32.Vec2 {
+	tangent := p.TangentAt(time)
+	return mgl32.Vec2{-tangent.Y(), tangent.X()}
+}
+
+// bezierDerivative : Returns the derivative of the cubic Bezier segment
+// at parameter t
+func bezierDerivative(s [4]mgl32.Vec2, t float32) mgl32.Vec2 {
+	u := 1 - t
+	return s[1].Sub(s[0]).Mul(3 * u * u).
+		Add(s[2].Sub(s[1]).Mul(6 * u * t)).
+		Add(s[3].Sub(s[2]).Mul(3 * t * t))
+}
+
+// angleOf : Returns the angle in radians of the direction vector
+func angleOf(v mgl32.Vec2) float64 {
+	return math.Atan2(float64(v.Y()), float64(v.X()))
+}
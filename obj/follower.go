@@ -0,0 +1,120 @@
+package obj
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+)
+
+// EndMode : What a Follower does when it reaches the end of its path
+type EndMode int
+
+const (
+	// Clamp : Stop at the end of the path
+	Clamp EndMode = iota
+	// Loop : Jump back to the start of the path
+	Loop
+	// PingPong : Reverse direction at each end of the path
+	PingPong
+)
+
+// Follower : Moves an object along a path at a constant speed in units per
+// second.  If Orient is set the object is rotated to face along the path,
+// offset by OrientOffset radians.  Register Update to drive the follower:
+//
+//	follower := obj.FollowPath(sprite, path, 100)
+//	follower.Mode = obj.PingPong
+//	g.OnUpdate(follower.Update)
+type Follower struct {
+	Object       *gologo.Object
+	Path         *Path
+	Speed        float32
+	Mode         EndMode
+	Orient       bool
+	OrientOffset float64
+	Distance     float32
+
+	reversed bool
+}
+
+// FollowPath : Creates a follower which moves the object from the start
+// of the path, placing it there immediately
+func FollowPath(object *gologo.Object, path *Path, speed float32) *Follower {
+	follower := &Follower{
+		Object: object,
+		Path:   path,
+		Speed:  speed,
+		Orient: true,
+	}
+	follower.apply()
+	return follower
+}
+
+// Update : Advances the follower by dt seconds
+func (f *Follower) Update(dt float64) {
+	length := f.Path.Length()
+	step := f.Speed * float32(dt)
+	if f.reversed {
+		step = -step
+	}
+	f.Distance += step
+
+	switch f.Mode {
+	case Clamp:
+		f.Distance = clampFloat32(f.Distance, 0, length)
+	case Loop:
+		if length > 0 {
+			f.Distance = float32(math.Mod(float64(f.Distance), float64(length)))
+			if f.Distance < 0 {
+				f.Distance += length
+			}
+		}
+	case PingPong:
+		for length > 0 && (f.Distance > length || f.Distance < 0) {
+			if f.Distance > length {
+				f.Distance = 2*length - f.Distance
+			} else {
+				f.Distance = -f.Distance
+			}
+			f.reversed = !f.reversed
+		}
+	}
+
+	f.apply()
+}
+
+// Done : Returns true if the follower is clamped at the end of its path
+func (f *Follower) Done() bool {
+	if f.Mode != Clamp {
+		return false
+	}
+	if f.Speed < 0 {
+		return f.Distance <= 0
+	}
+	return f.Distance >= f.Path.Length()
+}
+
+// apply : Moves the object to the follower's position on the path
+func (f *Follower) apply() {
+	position := f.Path.PositionAtDistance(f.Distance)
+	f.Object.Position = mgl32.Vec3{position.X(), position.Y(), f.Object.Position.Z()}
+
+	if f.Orient {
+		angle := angleOf(f.Path.TangentAtDistance(f.Distance))
+		if f.reversed != (f.Speed < 0) {
+			angle += math.Pi
+		}
+		f.Object.Orientation = angle + f.OrientOffset
+	}
+}
+
+func clampFloat32(v float32, lower float32, upper float32) float32 {
+	if v < lower {
+		return lower
+	}
+	if v > upper {
+		return upper
+	}
+	return v
+}
@@ -0,0 +1,213 @@
+package obj
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+	"github.com/leedenison/gologo/render"
+	"github.com/leedenison/gologo/time"
+)
+
+// Gradient : A fill whose color varies across world space.  Stops are in
+// the format used by render.Colors.  A linear gradient runs from offset 0
+// at Start to offset 1 at End and is constant at right angles to that
+// line.  A radial gradient runs from offset 0 at Start to offset 1 on the
+// circle through End.
+type Gradient struct {
+	Stops  []float64
+	Start  mgl32.Vec2
+	End    mgl32.Vec2
+	Radial bool
+}
+
+const (
+	// gradientTolerance : The largest difference in any color component
+	// allowed between the gradient and the colors interpolated across a
+	// triangle before it is divided
+	gradientTolerance = 1.5 / 255.0
+	// maxGradientDepth : The number of times a triangle may be divided
+	// into four
+	maxGradientDepth = 6
+)
+
+// LinearGradient : Returns a gradient from start to end
+func LinearGradient(start mgl32.Vec2, end mgl32.Vec2, stops []float64) Gradient {
+	return Gradient{Stops: stops, Start: start, End: end}
+}
+
+// RadialGradient : Returns a gradient from the center out to the radius
+func RadialGradient(center mgl32.Vec2, radius float32, stops []float64) Gradient {
+	return Gradient{
+		Stops:  stops,
+		Start:  center,
+		End:    center.Add(mgl32.Vec2{radius, 0}),
+		Radial: true,
+	}
+}
+
+// Offset : Returns the offset along the gradient of the world space point
+func (g Gradient) Offset(point mgl32.Vec2) float64 {
+	axis := g.End.Sub(g.Start)
+	if axis.Len() == 0 {
+		return 0
+	}
+
+	if g.Radial {
+		return float64(point.Sub(g.Start).Len() / axis.Len())
+	}
+	return float64(point.Sub(g.Start).Dot(axis) / axis.Dot(axis))
+}
+
+// ColorAt : Returns the color of the gradient at the world space point
+func (g Gradient) ColorAt(point mgl32.Vec2) mgl32.Vec4 {
+	c := render.GradientColor(g.Stops, g.Offset(point))
+	return mgl32.Vec4{
+		float32(c.R) / 255.0,
+		float32(c.G) / 255.0,
+		float32(c.B) / 255.0,
+		float32(c.A) / 255.0,
+	}
+}
+
+// GradientMesh : Returns an object drawing the triangles, given as
+// consecutive triples of world space points, filled with the gradient.
+// The object origin is the center of the triangles' bounding box.  name
+// is used in errors.
+func GradientMesh(triangles []mgl32.Vec2, gradient Gradient, name string) *gologo.Object {
+	min, max := bounds(triangles)
+	return GradientMeshAt(min.Add(max).Mul(0.5), triangles, gradient, name)
+}
+
+// GradientMeshAt : Returns an object drawing the triangles filled with the
+// gradient, with its origin at the world space point
+func GradientMeshAt(origin mgl32.Vec2, triangles []mgl32.Vec2, gradient Gradient, name string) *gologo.Object {
+	meshVertices := gradientVertices(meshVertices(origin, triangles), func(p mgl32.Vec2) mgl32.Vec4 {
+		return gradient.ColorAt(p.Add(origin))
+	})
+
+	meshRenderer, err := createGradientRenderer(meshVertices)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to create %v renderer: %v\n", name, err))
+	}
+
+	return &gologo.Object{
+		Position: mgl32.Vec3{origin[0], origin[1], 0.0},
+		Scale:    1.0,
+		Creation: time.GetTickTime(),
+		ZOrder:   0,
+		Renderer: meshRenderer,
+	}
+}
+
+// FillGradient : Replaces the fill of a shape created by this package
+// with the gradient.  The gradient is placed in world space according to
+// the current position, rotation and scale of the object and moves with
+// it afterwards.  Panics if the object is not drawn by a MeshRenderer.
+func FillGradient(object *gologo.Object, gradient Gradient) {
+	meshRenderer, ok := object.Renderer.(*render.MeshRenderer)
+	if !ok || meshRenderer.Shader.VertexStride() != render.GlMeshStride {
+		panic(fmt.Sprintf("Failed to fill object with gradient: unsupported renderer %T\n", object.Renderer))
+	}
+
+	model := object.GetModel()
+	meshVertices := gradientVertices(meshRenderer.MeshVertices, func(p mgl32.Vec2) mgl32.Vec4 {
+		return gradient.ColorAt(model.Mul4x1(p.Vec4(0, 1)).Vec2())
+	})
+
+	gradientRenderer, err := createGradientRenderer(meshVertices)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to fill object with gradient: %v\n", err))
+	}
+	object.Renderer = gradientRenderer
+}
+
+func createGradientRenderer(meshVertices []float32) (*render.MeshRenderer, error) {
+	return render.CreateMeshRenderer(
+		"COLOR_VERTEX_SHADER",
+		"VERTEX_COLOR_FRAGMENT_SHADER",
+		[]int{render.UniformAlpha},
+		map[int]interface{}{
+			render.UniformAlpha: float32(1.0),
+		},
+		meshVertices)
+}
+
+// gradientVertices : Returns the mesh vertices, in GlMeshStride format,
+// converted to GlColorMeshStride format with the color of each vertex
+// given by colorAt its position.  Triangles are divided where the colors
+// interpolated between their corners would differ visibly from colorAt.
+func gradientVertices(meshVertices []float32, colorAt func(p mgl32.Vec2) mgl32.Vec4) []float32 {
+	stride := render.GlMeshStride
+	result := make([]float32, 0, len(meshVertices)/stride*render.GlColorMeshStride)
+
+	for i := 0; i+3*stride <= len(meshVertices); i += 3 * stride {
+		result = appendGradientTriangle(result,
+			meshVertices[i:i+stride],
+			meshVertices[i+stride:i+2*stride],
+			meshVertices[i+2*stride:i+3*stride],
+			colorAt, 0)
+	}
+
+	return result
+}
+
+func appendGradientTriangle(result []float32, a []float32, b []float32, c []float32, colorAt func(p mgl32.Vec2) mgl32.Vec4, depth int) []float32 {
+	ab := lerpVertex(a, b, 0.5)
+	bc := lerpVertex(b, c, 0.5)
+	ca := lerpVertex(c, a, 0.5)
+
+	if depth < maxGradientDepth && !interpolatesColor(a, b, c, ab, bc, ca, colorAt) {
+		result = appendGradientTriangle(result, a, ab, ca, colorAt, depth+1)
+		result = appendGradientTriangle(result, ab, b, bc, colorAt, depth+1)
+		result = appendGradientTriangle(result, ca, bc, c, colorAt, depth+1)
+		return appendGradientTriangle(result, ab, bc, ca, colorAt, depth+1)
+	}
+
+	for _, v := range [][]float32{a, b, c} {
+		color := colorAt(mgl32.Vec2{v[0], v[1]})
+		result = append(result, v[:render.GlMeshStride]...)
+		result = append(result, color[:]...)
+	}
+	return result
+}
+
+// interpolatesColor : Returns true if the colors at the midpoints of the
+// edges of the triangle, and at its centroid, are close enough to the
+// colors interpolated from its corners
+func interpolatesColor(a []float32, b []float32, c []float32, ab []float32, bc []float32, ca []float32, colorAt func(p mgl32.Vec2) mgl32.Vec4) bool {
+	ca0 := colorAt(mgl32.Vec2{a[0], a[1]})
+	cb0 := colorAt(mgl32.Vec2{b[0], b[1]})
+	cc0 := colorAt(mgl32.Vec2{c[0], c[1]})
+
+	samples := []struct {
+		vertex   []float32
+		expected mgl32.Vec4
+	}{
+		{ab, ca0.Add(cb0).Mul(0.5)},
+		{bc, cb0.Add(cc0).Mul(0.5)},
+		{ca, cc0.Add(ca0).Mul(0.5)},
+		{lerpVertex(ab, c, 1.0/3.0), ca0.Add(cb0).Add(cc0).Mul(1.0 / 3.0)},
+	}
+
+	for _, sample := range samples {
+		actual := colorAt(mgl32.Vec2{sample.vertex[0], sample.vertex[1]})
+		for j := 0; j < 4; j++ {
+			if math.Abs(float64(actual[j]-sample.expected[j])) > gradientTolerance {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// lerpVertex : Returns the mesh vertex the fraction t of the way from a
+// to b, interpolating the texture co-ordinates
+func lerpVertex(a []float32, b []float32, t float32) []float32 {
+	result := make([]float32, render.GlMeshStride)
+	for i := range result {
+		result[i] = a[i] + (b[i]-a[i])*t
+	}
+	return result
+}
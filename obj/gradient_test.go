@@ -0,0 +1,126 @@
+package obj
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+	"github.com/leedenison/gologo/gologotest"
+	"github.com/leedenison/gologo/render"
+)
+
+// colorDistance : Returns the largest difference between the components of
+// the colors
+func colorDistance(a mgl32.Vec4, b mgl32.Vec4) float64 {
+	var result float64
+	for i := 0; i < 4; i++ {
+		result = math.Max(result, math.Abs(float64(a[i]-b[i])))
+	}
+	return result
+}
+
+var stops = []float64{
+	0.0, 255, 0, 0,
+	1.0, 0, 0, 255,
+}
+
+var offsetTests = []struct {
+	name     string
+	gradient Gradient
+	point    mgl32.Vec2
+	offset   float64
+}{
+	{"linear start", LinearGradient(mgl32.Vec2{10, 0}, mgl32.Vec2{30, 0}, stops), mgl32.Vec2{10, 50}, 0},
+	{"linear middle", LinearGradient(mgl32.Vec2{10, 0}, mgl32.Vec2{30, 0}, stops), mgl32.Vec2{20, -5}, 0.5},
+	{"linear diagonal", LinearGradient(mgl32.Vec2{0, 0}, mgl32.Vec2{10, 10}, stops), mgl32.Vec2{10, 0}, 0.5},
+	{"radial center", RadialGradient(mgl32.Vec2{5, 5}, 10, stops), mgl32.Vec2{5, 5}, 0},
+	{"radial edge", RadialGradient(mgl32.Vec2{5, 5}, 10, stops), mgl32.Vec2{5, -5}, 1},
+	{"radial outside", RadialGradient(mgl32.Vec2{5, 5}, 10, stops), mgl32.Vec2{25, 5}, 2},
+}
+
+// TestGradientOffset : Test the offset along linear and radial gradients
+func TestGradientOffset(t *testing.T) {
+	for _, test := range offsetTests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.gradient.Offset(test.point)
+			if math.Abs(got-test.offset) > epsilon {
+				t.Errorf("Offset was (%v) should be (%v)", got, test.offset)
+			}
+		})
+	}
+}
+
+// TestGradientVertices : Test that a two stop linear gradient is drawn
+// without dividing triangles, whereas a radial gradient is divided until
+// the interpolated colors match
+func TestGradientVertices(t *testing.T) {
+	triangles := []mgl32.Vec2{{0, 0}, {100, 0}, {100, 100}, {0, 0}, {100, 100}, {0, 100}}
+	vertices := meshVertices(mgl32.Vec2{}, triangles)
+
+	linear := LinearGradient(mgl32.Vec2{0, 0}, mgl32.Vec2{100, 0}, stops)
+	result := gradientVertices(vertices, linear.ColorAt)
+	if len(result) != 6*render.GlColorMeshStride {
+		t.Errorf("Linear vertices was (%v) should be (%v)", len(result), 6*render.GlColorMeshStride)
+	}
+
+	color := mgl32.Vec4{result[14], result[15], result[16], result[17]}
+	if colorDistance(color, mgl32.Vec4{0, 0, 1, 1}) > epsilon {
+		t.Errorf("Color was (%v) should be (%v)", color, mgl32.Vec4{0, 0, 1, 1})
+	}
+
+	radial := RadialGradient(mgl32.Vec2{50, 50}, 50, stops)
+	result = gradientVertices(vertices, radial.ColorAt)
+	if len(result) <= 6*render.GlColorMeshStride {
+		t.Errorf("Radial vertices was (%v) should be more than (%v)", len(result), 6*render.GlColorMeshStride)
+	}
+
+	for i := 0; i < len(result); i += 3 * render.GlColorMeshStride {
+		a := mgl32.Vec2{result[i], result[i+1]}
+		b := mgl32.Vec2{result[i+render.GlColorMeshStride], result[i+render.GlColorMeshStride+1]}
+		c := mgl32.Vec2{result[i+2*render.GlColorMeshStride], result[i+2*render.GlColorMeshStride+1]}
+		centroid := a.Add(b).Add(c).Mul(1.0 / 3.0)
+		expected := radial.ColorAt(centroid)
+		var interpolated mgl32.Vec4
+		for j := 0; j < 3; j++ {
+			k := i + j*render.GlColorMeshStride + render.GlMeshStride
+			interpolated = interpolated.Add(mgl32.Vec4{result[k], result[k+1], result[k+2], result[k+3]}.Mul(1.0 / 3.0))
+		}
+		if colorDistance(interpolated, expected) > 0.05 {
+			t.Fatalf("Color at (%v) was (%v) should be (%v)", centroid, interpolated, expected)
+		}
+	}
+}
+
+// TestGradientsGolden : Test linear and radial gradient fills of shapes
+// from this package
+func TestGradientsGolden(t *testing.T) {
+	g := gologotest.Init(160, 120)
+	defer g.Close()
+
+	rainbow := []float64{
+		0.0, 255, 0, 0,
+		0.5, 0, 255, 0,
+		1.0, 0, 0, 255,
+	}
+	white := mgl32.Vec4{1.0, 1.0, 1.0, 1.0}
+
+	square := Rectangle(gologo.Rect{{10, 10}, {70, 50}}, white)
+	FillGradient(square, LinearGradient(mgl32.Vec2{10, 0}, mgl32.Vec2{70, 0}, rainbow))
+
+	circle := Circle(mgl32.Vec2{115, 30}, 25, white)
+	FillGradient(circle, RadialGradient(mgl32.Vec2{115, 30}, 25, []float64{
+		0.0, 255, 255, 0,
+		1.0, 255, 0, 0,
+	}))
+
+	star := Star(mgl32.Vec2{40, 85}, 5, 28, 12, white)
+	FillGradient(star, LinearGradient(mgl32.Vec2{0, 60}, mgl32.Vec2{0, 110}, rainbow))
+
+	triangle := GradientMesh(
+		[]mgl32.Vec2{{90, 65}, {150, 65}, {120, 112}},
+		RadialGradient(mgl32.Vec2{120, 80}, 35, rainbow),
+		"Triangle")
+
+	gologotest.AssertScene(t, g, "gradients", []*gologo.Object{square, circle, star, triangle}, 0)
+}
@@ -0,0 +1,321 @@
+package obj
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+)
+
+// The shapes below each have a filled variant and an Outline variant drawn
+// with a StrokeStyle.  Curved edges are divided into enough straight lines
+// to stay within a quarter of a unit of the true curve.  The lines are
+// chosen once, when the shape is created, from its size in world units:
+// scaling the object or its parents, or changing the projection, does not
+// divide the curves again, so a small shape drawn enlarged shows its
+// corners.  Angles are in radians counterclockwise from the positive X
+// axis.
+
+// Circle : Returns a filled circle
+func Circle(center mgl32.Vec2, radius float32, color mgl32.Vec4) *gologo.Object {
+	return Ellipse(center, radius, radius, color)
+}
+
+// CircleOutline : Returns the outline of a circle
+func CircleOutline(center mgl32.Vec2, radius float32, style StrokeStyle, color mgl32.Vec4) *gologo.Object {
+	return EllipseOutline(center, radius, radius, style, color)
+}
+
+// Ellipse : Returns a filled ellipse with radii rx and ry along the X and
+// Y axes
+func Ellipse(center mgl32.Vec2, rx float32, ry float32, color mgl32.Vec4) *gologo.Object {
+	outline := ellipsePoints(center, rx, ry, 0, 2*math.Pi)
+	return ColorMeshAt(center, fan(center, outline, true), color, "Ellipse")
+}
+
+// EllipseOutline : Returns the outline of an ellipse
+func EllipseOutline(center mgl32.Vec2, rx float32, ry float32, style StrokeStyle, color mgl32.Vec4) *gologo.Object {
+	style.Closed = true
+	outline := ellipsePoints(center, rx, ry, 0, 2*math.Pi)
+	return ColorMeshAt(center, strokeTriangles(outline, style), color, "EllipseOutline")
+}
+
+// Arc : Returns a filled band of the given width along the arc of a circle,
+// from angle start through sweep radians.  The origin is the circle center.
+func Arc(center mgl32.Vec2, radius float32, start float64, sweep float64, width float32, color mgl32.Vec4) *gologo.Object {
+	return ColorMeshAt(center, arcTriangles(center, radius, start, sweep, width), color, "Arc")
+}
+
+// ArcOutline : Returns a stroked line along the arc of a circle, from angle
+// start through sweep radians.  The origin is the circle center.
+func ArcOutline(center mgl32.Vec2, radius float32, start float64, sweep float64, style StrokeStyle, color mgl32.Vec4) *gologo.Object {
+	style.Closed = fullTurn(sweep)
+	outline := ellipsePoints(center, radius, radius, start, sweep)
+	return ColorMeshAt(center, strokeTriangles(outline, style), color, "ArcOutline")
+}
+
+// Sector : Returns a filled pie slice of a circle, from angle start through
+// sweep radians.  The origin is the circle center.
+func Sector(center mgl32.Vec2, radius float32, start float64, sweep float64, color mgl32.Vec4) *gologo.Object {
+	return ColorMeshAt(center, sectorTriangles(center, radius, start, sweep), color, "Sector")
+}
+
+// SectorOutline : Returns the outline of a pie slice of a circle
+func SectorOutline(center mgl32.Vec2, radius float32, start float64, sweep float64, style StrokeStyle, color mgl32.Vec4) *gologo.Object {
+	style.Closed = true
+	arc := ellipsePoints(center, radius, radius, start, sweep)
+	if fullTurn(sweep) {
+		arc = append(arc, arc[0])
+	}
+	outline := append([]mgl32.Vec2{center}, arc...)
+	return ColorMeshAt(center, strokeTriangles(outline, style), color, "SectorOutline")
+}
+
+// RoundedRectangle : Returns a filled rectangle with corners rounded to the
+// radius, which is limited to half the shorter side
+func RoundedRectangle(rect gologo.Rect, radius float32, color mgl32.Vec4) *gologo.Object {
+	center, outline := roundedRectanglePoints(rect, radius)
+	return ColorMeshAt(center, fan(center, outline, true), color, "RoundedRectangle")
+}
+
+// RoundedRectangleOutline : Returns the outline of a rounded rectangle
+func RoundedRectangleOutline(rect gologo.Rect, radius float32, style StrokeStyle, color mgl32.Vec4) *gologo.Object {
+	style.Closed = true
+	center, outline := roundedRectanglePoints(rect, radius)
+	return ColorMeshAt(center, strokeTriangles(outline, style), color, "RoundedRectangleOutline")
+}
+
+// Star : Returns a filled star with the number of points, alternating
+// between the outer and inner radius, with the first point straight up
+func Star(center mgl32.Vec2, points int, outerRadius float32, innerRadius float32, color mgl32.Vec4) *gologo.Object {
+	outline := starPoints(center, points, outerRadius, innerRadius)
+	return ColorMeshAt(center, fan(center, outline, true), color, "Star")
+}
+
+// StarOutline : Returns the outline of a star
+func StarOutline(center mgl32.Vec2, points int, outerRadius float32, innerRadius float32, style StrokeStyle, color mgl32.Vec4) *gologo.Object {
+	style.Closed = true
+	outline := starPoints(center, points, outerRadius, innerRadius)
+	return ColorMeshAt(center, strokeTriangles(outline, style), color, "StarOutline")
+}
+
+// Ring : Returns a filled ring between the inner and outer radius
+func Ring(center mgl32.Vec2, outerRadius float32, innerRadius float32, color mgl32.Vec4) *gologo.Object {
+	outer, inner := bandPoints(center, outerRadius, innerRadius, 0, 2*math.Pi)
+	return ColorMeshAt(center, band(outer, inner, true), color, "Ring")
+}
+
+// RingOutline : Returns the inner and outer outlines of a ring
+func RingOutline(center mgl32.Vec2, outerRadius float32, innerRadius float32, style StrokeStyle, color mgl32.Vec4) *gologo.Object {
+	style.Closed = true
+	outer := ellipsePoints(center, outerRadius, outerRadius, 0, 2*math.Pi)
+	inner := ellipsePoints(center, innerRadius, innerRadius, 0, 2*math.Pi)
+	triangles := append(strokeTriangles(outer, style), strokeTriangles(inner, style)...)
+	return ColorMeshAt(center, triangles, color, "RingOutline")
+}
+
+// Arrow : Returns a filled arrow from tail to tip, with a shaft of width
+// shaftWidth and a triangular head headWidth wide and headLength long.
+// The origin is halfway between tail and tip.
+func Arrow(tail mgl32.Vec2, tip mgl32.Vec2, shaftWidth float32, headWidth float32, headLength float32, color mgl32.Vec4) *gologo.Object {
+	outline := arrowPoints(tail, tip, shaftWidth, headWidth, headLength)
+	center := tail.Add(tip).Mul(0.5)
+	return ColorMeshAt(center, Triangulate([][]mgl32.Vec2{outline}, NonZero), color, "Arrow")
+}
+
+// ArrowOutline : Returns the outline of an arrow
+func ArrowOutline(tail mgl32.Vec2, tip mgl32.Vec2, shaftWidth float32, headWidth float32, headLength float32, style StrokeStyle, color mgl32.Vec4) *gologo.Object {
+	style.Closed = true
+	outline := arrowPoints(tail, tip, shaftWidth, headWidth, headLength)
+	center := tail.Add(tip).Mul(0.5)
+	return ColorMeshAt(center, strokeTriangles(outline, style), color, "ArrowOutline")
+}
+
+/////////////////////////////////////////////////////////////
+// Outlines
+//
+
+// arcTriangles : Returns the triangles of a band of the given width along
+// the arc of a circle, closed if the sweep is a full turn
+func arcTriangles(center mgl32.Vec2, radius float32, start float64, sweep float64, width float32) []mgl32.Vec2 {
+	outer, inner := bandPoints(center, radius+width/2, radius-width/2, start, sweep)
+	return band(outer, inner, fullTurn(sweep))
+}
+
+// sectorTriangles : Returns the triangles of a pie slice of a circle,
+// closed if the sweep is a full turn
+func sectorTriangles(center mgl32.Vec2, radius float32, start float64, sweep float64) []mgl32.Vec2 {
+	outline := ellipsePoints(center, radius, radius, start, sweep)
+	return fan(center, outline, fullTurn(sweep))
+}
+
+// ellipsePoints : Returns points along the ellipse from angle start through
+// sweep radians, including both ends.  A full turn does not repeat the
+// first point.
+func ellipsePoints(center mgl32.Vec2, rx float32, ry float32, start float64, sweep float64) []mgl32.Vec2 {
+	radius := rx
+	if ry > radius {
+		radius = ry
+	}
+	return ellipseSegments(center, rx, ry, start, sweep, segmentCount(radius, sweep))
+}
+
+// ellipseSegments : Returns points along the ellipse as for ellipsePoints,
+// divided into count segments
+func ellipseSegments(center mgl32.Vec2, rx float32, ry float32, start float64, sweep float64, count int) []mgl32.Vec2 {
+	last := count
+	if fullTurn(sweep) {
+		last = count - 1
+	}
+
+	result := make([]mgl32.Vec2, 0, last+1)
+	for i := 0; i <= last; i++ {
+		angle := start + sweep*float64(i)/float64(count)
+		result = append(result, center.Add(mgl32.Vec2{
+			rx * float32(math.Cos(angle)),
+			ry * float32(math.Sin(angle)),
+		}))
+	}
+	return result
+}
+
+// segmentCount : Returns the number of segments needed to draw an arc of
+// the radius, in world units, through sweep radians.  Full turns have at least 8 segments.
+func segmentCount(radius float32, sweep float64) int {
+	count := arcSegmentCount(radius, math.Abs(sweep), defaultTolerance)
+	if fullTurn(sweep) && count < 8 {
+		count = 8
+	}
+	return count
+}
+
+// fullTurn : Returns true if the sweep is a full turn or more, so that
+// the points returned by ellipsePoints must be closed back to the first
+func fullTurn(sweep float64) bool {
+	return math.Abs(sweep) >= 2*math.Pi
+}
+
+// bandPoints : Returns the outer and inner outlines of a band between two
+// circles, with the same number of points
+func bandPoints(center mgl32.Vec2, outerRadius float32, innerRadius float32, start float64, sweep float64) ([]mgl32.Vec2, []mgl32.Vec2) {
+	count := segmentCount(outerRadius, sweep)
+	return ellipseSegments(center, outerRadius, outerRadius, start, sweep, count),
+		ellipseSegments(center, innerRadius, innerRadius, start, sweep, count)
+}
+
+func roundedRectanglePoints(rect gologo.Rect, radius float32) (mgl32.Vec2, []mgl32.Vec2) {
+	xMin, xMax, yMin, yMax := rectMinMax(rect)
+	center := mgl32.Vec2{(xMin + xMax) / 2, (yMin + yMax) / 2}
+
+	limit := float32(math.Min(float64(xMax-xMin), float64(yMax-yMin))) / 2
+	if radius > limit {
+		radius = limit
+	}
+	if radius <= 0 {
+		return center, []mgl32.Vec2{{xMax, yMin}, {xMax, yMax}, {xMin, yMax}, {xMin, yMin}}
+	}
+
+	corners := []struct {
+		center mgl32.Vec2
+		start  float64
+	}{
+		{mgl32.Vec2{xMax - radius, yMin + radius}, -math.Pi / 2},
+		{mgl32.Vec2{xMax - radius, yMax - radius}, 0},
+		{mgl32.Vec2{xMin + radius, yMax - radius}, math.Pi / 2},
+		{mgl32.Vec2{xMin + radius, yMin + radius}, math.Pi},
+	}
+
+	result := []mgl32.Vec2{}
+	for _, corner := range corners {
+		result = append(result, ellipsePoints(corner.center, radius, radius, corner.start, math.Pi/2)...)
+	}
+	return center, removeDuplicates(result)
+}
+
+func starPoints(center mgl32.Vec2, points int, outerRadius float32, innerRadius float32) []mgl32.Vec2 {
+	if points < 2 {
+		panic("Star must have at least two points")
+	}
+
+	result := []mgl32.Vec2{}
+	for i := 0; i < 2*points; i++ {
+		radius := outerRadius
+		if i%2 == 1 {
+			radius = innerRadius
+		}
+		angle := math.Pi/2 + float64(i)*math.Pi/float64(points)
+		result = append(result, center.Add(mgl32.Vec2{
+			radius * float32(math.Cos(angle)),
+			radius * float32(math.Sin(angle)),
+		}))
+	}
+	return result
+}
+
+func arrowPoints(tail mgl32.Vec2, tip mgl32.Vec2, shaftWidth float32, headWidth float32, headLength float32) []mgl32.Vec2 {
+	if tail == tip {
+		panic("Arrow tail and tip must differ")
+	}
+
+	d := tip.Sub(tail).Normalize()
+	n := mgl32.Vec2{-d.Y(), d.X()}
+	neck := tip.Sub(d.Mul(headLength))
+
+	return []mgl32.Vec2{
+		tail.Sub(n.Mul(shaftWidth / 2)),
+		neck.Sub(n.Mul(shaftWidth / 2)),
+		neck.Sub(n.Mul(headWidth / 2)),
+		tip,
+		neck.Add(n.Mul(headWidth / 2)),
+		neck.Add(n.Mul(shaftWidth / 2)),
+		tail.Add(n.Mul(shaftWidth / 2)),
+	}
+}
+
+// rectMinMax : Returns the minimum and maximum X and Y of the rectangle
+func rectMinMax(rect gologo.Rect) (float32, float32, float32, float32) {
+	xMin := float32(math.Min(float64(rect[0][0]), float64(rect[1][0])))
+	xMax := float32(math.Max(float64(rect[0][0]), float64(rect[1][0])))
+	yMin := float32(math.Min(float64(rect[0][1]), float64(rect[1][1])))
+	yMax := float32(math.Max(float64(rect[0][1]), float64(rect[1][1])))
+	return xMin, xMax, yMin, yMax
+}
+
+/////////////////////////////////////////////////////////////
+// Triangles
+//
+
+// fan : Returns triangles from the center to each edge of the outline,
+// joining the last point back to the first if closed.  The outline must be
+// visible in full from the center.
+func fan(center mgl32.Vec2, outline []mgl32.Vec2, closed bool) []mgl32.Vec2 {
+	count := len(outline) - 1
+	if closed {
+		count = len(outline)
+	}
+
+	result := make([]mgl32.Vec2, 0, 3*count)
+	for i := 0; i < count; i++ {
+		result = append(result, center, outline[i], outline[(i+1)%len(outline)])
+	}
+	return result
+}
+
+// band : Returns triangles joining corresponding points on two outlines
+// with the same number of points, joining the last points back to the
+// first if closed
+func band(outer []mgl32.Vec2, inner []mgl32.Vec2, closed bool) []mgl32.Vec2 {
+	count := len(outer) - 1
+	if closed {
+		count = len(outer)
+	}
+
+	result := make([]mgl32.Vec2, 0, 6*count)
+	for i := 0; i < count; i++ {
+		j := (i + 1) % len(outer)
+		result = append(result,
+			outer[i], outer[j], inner[j],
+			outer[i], inner[j], inner[i])
+	}
+	return result
+}
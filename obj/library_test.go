@@ -0,0 +1,119 @@
+package obj
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+	"github.com/leedenison/gologo/gologotest"
+)
+
+var center = mgl32.Vec2{10, 20}
+
+var libraryAreaTests = []struct {
+	name      string
+	triangles []mgl32.Vec2
+	area      float64
+}{
+	{"circle", fan(center, ellipsePoints(center, 50, 50, 0, 2*math.Pi), true), 2500 * math.Pi},
+	{"ellipse", fan(center, ellipsePoints(center, 50, 20, 0, 2*math.Pi), true), 1000 * math.Pi},
+	{"sector", sectorTriangles(center, 50, 1, math.Pi/2), 625 * math.Pi},
+	{"full sector", sectorTriangles(center, 50, 1, 2*math.Pi), 2500 * math.Pi},
+	{"arc", arcTriangles(center, 50, 0, -math.Pi, 10), 500 * math.Pi},
+	{"full arc", arcTriangles(center, 50, 0, -2*math.Pi, 10), 1000 * math.Pi},
+	{"ring", func() []mgl32.Vec2 {
+		outer, inner := bandPoints(center, 50, 30, 0, 2*math.Pi)
+		return band(outer, inner, true)
+	}(), 1600 * math.Pi},
+	{"rounded rectangle", func() []mgl32.Vec2 {
+		c, outline := roundedRectanglePoints(gologo.Rect{{100, 0}, {0, 50}}, 10)
+		return fan(c, outline, true)
+	}(), 5000 - (4-math.Pi)*100},
+	{"star", fan(center, starPoints(center, 5, 40, 20), true), 10 * 400 * math.Sin(math.Pi/5)},
+	{"arrow", Triangulate([][]mgl32.Vec2{
+		arrowPoints(mgl32.Vec2{0, 0}, mgl32.Vec2{100, 0}, 10, 30, 20)}, NonZero), 80*10 + 30*20/2},
+}
+
+// TestLibraryArea : Test the area covered by each filled shape
+func TestLibraryArea(t *testing.T) {
+	for _, test := range libraryAreaTests {
+		t.Run(test.name, func(t *testing.T) {
+			got := area(test.triangles)
+			if math.Abs(got-test.area) > 0.01*test.area {
+				t.Errorf("Area was (%v) should be (%v)", got, test.area)
+			}
+		})
+	}
+}
+
+var segmentTests = []struct {
+	radius float32
+	min    int
+	max    int
+}{
+	{1, 8, 8},
+	{10, 12, 30},
+	{100, 40, 100},
+}
+
+// TestCircleSegments : Test that larger circles are divided into more
+// segments
+func TestCircleSegments(t *testing.T) {
+	previous := 0
+	for _, test := range segmentTests {
+		got := len(ellipsePoints(mgl32.Vec2{}, test.radius, test.radius, 0, 2*math.Pi))
+		if got < test.min || got > test.max || got <= previous && test.min != test.max {
+			t.Errorf("Segments for radius (%v) was (%v) should be in [%v, %v]", test.radius, got, test.min, test.max)
+		}
+		previous = got
+	}
+}
+
+// TestStarPoints : Test that the first point of a star is straight up and
+// points alternate between the outer and inner radius
+func TestStarPoints(t *testing.T) {
+	points := starPoints(mgl32.Vec2{}, 5, 40, 20)
+	if len(points) != 10 {
+		t.Fatalf("Points was (%v) should be (%v)", len(points), 10)
+	}
+	if !points[0].ApproxEqualThreshold(mgl32.Vec2{0, 40}, epsilon) {
+		t.Errorf("First point was (%v) should be (%v)", points[0], mgl32.Vec2{0, 40})
+	}
+	for i, p := range points {
+		expected := float32(40)
+		if i%2 == 1 {
+			expected = 20
+		}
+		if math.Abs(float64(p.Len()-expected)) > epsilon {
+			t.Errorf("Radius of point %v was (%v) should be (%v)", i, p.Len(), expected)
+		}
+	}
+}
+
+// TestLibraryGolden : Test the filled and outlined shapes from the shape
+// library
+func TestLibraryGolden(t *testing.T) {
+	g := gologotest.Init(160, 120)
+	defer g.Close()
+
+	outline := StrokeStyle{Width: 2}
+	white := mgl32.Vec4{1.0, 1.0, 1.0, 1.0}
+
+	objects := []*gologo.Object{
+		Circle(mgl32.Vec2{20, 20}, 14, mgl32.Vec4{1.0, 0.0, 0.0, 1.0}),
+		CircleOutline(mgl32.Vec2{20, 20}, 14, outline, white),
+		Ellipse(mgl32.Vec2{60, 20}, 18, 10, mgl32.Vec4{0.0, 1.0, 0.0, 1.0}),
+		Sector(mgl32.Vec2{100, 20}, 15, 0, 1.5*math.Pi, mgl32.Vec4{1.0, 1.0, 0.0, 1.0}),
+		SectorOutline(mgl32.Vec2{100, 20}, 15, 0, 1.5*math.Pi, outline, white),
+		Arc(mgl32.Vec2{140, 15}, 12, 0, math.Pi, 4, mgl32.Vec4{0.0, 1.0, 1.0, 1.0}),
+		RoundedRectangle(gologo.Rect{{5, 45}, {55, 75}}, 8, mgl32.Vec4{0.0, 0.0, 1.0, 1.0}),
+		RoundedRectangleOutline(gologo.Rect{{5, 45}, {55, 75}}, 8, outline, white),
+		Star(mgl32.Vec2{85, 60}, 5, 18, 8, mgl32.Vec4{1.0, 0.5, 0.0, 1.0}),
+		Ring(mgl32.Vec2{135, 60}, 16, 10, mgl32.Vec4{1.0, 0.0, 1.0, 1.0}),
+		Arrow(mgl32.Vec2{10, 100}, mgl32.Vec2{70, 100}, 6, 18, 14, mgl32.Vec4{0.5, 0.5, 1.0, 1.0}),
+		ArrowOutline(mgl32.Vec2{90, 110}, mgl32.Vec2{150, 90}, 6, 18, 14, outline, white),
+	}
+
+	gologotest.AssertScene(t, g, "library", objects, 0)
+}
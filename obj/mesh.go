@@ -0,0 +1,88 @@
+package obj
+
+import (
+	"fmt"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+	"github.com/leedenison/gologo/render"
+	"github.com/leedenison/gologo/time"
+)
+
+// ColorMesh : Returns an object drawing the triangles, given as consecutive
+// triples of world space points, in a single color.  The object origin is
+// the center of the triangles' bounding box.  name is used in errors.
+func ColorMesh(triangles []mgl32.Vec2, color mgl32.Vec4, name string) *gologo.Object {
+	min, max := bounds(triangles)
+	return ColorMeshAt(min.Add(max).Mul(0.5), triangles, color, name)
+}
+
+// ColorMeshAt : Returns an object drawing the triangles in a single color,
+// with its origin at the world space point
+func ColorMeshAt(origin mgl32.Vec2, triangles []mgl32.Vec2, color mgl32.Vec4, name string) *gologo.Object {
+	meshVertices := meshVertices(origin, triangles)
+
+	meshRenderer, err := render.CreateMeshRenderer(
+		"ORTHO_VERTEX_SHADER",
+		"COLOR_FRAGMENT_SHADER",
+		[]int{render.UniformColor},
+		map[int]interface{}{
+			render.UniformColor: color,
+		},
+		meshVertices)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to create %v renderer: %v\n", name, err))
+	}
+
+	return &gologo.Object{
+		Position: mgl32.Vec3{origin[0], origin[1], 0.0},
+		Scale:    1.0,
+		Creation: time.GetTickTime(),
+		ZOrder:   0,
+		Renderer: meshRenderer,
+	}
+}
+
+// meshVertices : Returns the mesh vertices for the points relative to the
+// origin.  Texture co-ordinates span the bounding box of the points with v
+// increasing downwards, as for Rectangle.
+func meshVertices(origin mgl32.Vec2, points []mgl32.Vec2) []float32 {
+	min, max := bounds(points)
+	size := max.Sub(min)
+
+	result := make([]float32, 0, len(points)*render.GlMeshStride)
+	for _, p := range points {
+		var u, v float32
+		if size.X() > 0 {
+			u = (p.X() - min.X()) / size.X()
+		}
+		if size.Y() > 0 {
+			v = 1 - (p.Y()-min.Y())/size.Y()
+		}
+
+		result = append(result, p.X()-origin.X(), p.Y()-origin.Y(), 0.0, u, v)
+	}
+
+	return result
+}
+
+// bounds : Returns the minimum and maximum corners of the bounding box of
+// the points
+func bounds(points []mgl32.Vec2) (mgl32.Vec2, mgl32.Vec2) {
+	if len(points) == 0 {
+		return mgl32.Vec2{}, mgl32.Vec2{}
+	}
+
+	min, max := points[0], points[0]
+	for _, p := range points[1:] {
+		for i := 0; i < 2; i++ {
+			if p[i] < min[i] {
+				min[i] = p[i]
+			}
+			if p[i] > max[i] {
+				max[i] = p[i]
+			}
+		}
+	}
+	return min, max
+}
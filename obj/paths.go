@@ -5,32 +5,23 @@ import (
 )
 
 // Path : the list of vectors and times for a given path to follow
+// Each segment is a cubic Bezier curve of start point, two control points
+// and end point.  Times are the fraction of the total path time spent on
+// each segment, proportional to segment length, and sum to 1.  Closed is
+// set if the path returns to its start, for example from SVG "Z".
 type Path struct {
 	Segments [][4]mgl32.Vec2
 	Times    []float32
+	Closed   bool
+
+	table *arcTable
 }
 
-// GetPosition : returns the position along a path for a given time
+// GetPosition : returns the position along a path for a given time,
+// truncated to whole units.  Times past the end return the end of the path.
 func (p *Path) GetPosition(time float32) (int, int) {
-	var accumulator float32
-
-	for i := 0; i < len(p.Times); i++ {
-		if accumulator+p.Times[i] >= time {
-			segmentTime := (time - accumulator) / p.Times[i]
-			position := mgl32.CubicBezierCurve2D(
-				segmentTime,
-				p.Segments[i][0],
-				p.Segments[i][1],
-				p.Segments[i][2],
-				p.Segments[i][3])
-
-			return int(position.X()), int(position.Y())
-		}
-
-		accumulator += p.Times[i]
-	}
-
-	return 0, 0
+	position := p.PositionAt(time)
+	return int(position.X()), int(position.Y())
 }
 
 // CreatePath : Retuns a constructed path with times for a list of x, y coords for the path
@@ -45,34 +36,16 @@ func CreatePath(path []int) *Path {
 	if len(points)-1 == 1 {
 		segment := calcSingleSegment(points)
 		result.Segments = append(result.Segments, segment)
-		result.Times = []float32{1.0}
 	} else {
 		coefficients, input := calcInput(points)
 		controls := solveTriDiagonal(coefficients, input)
 		result.Segments = calcSegments(points, controls)
 
-		result.Times = make([]float32, len(result.Segments))
-
-		calcSegmentLengths(result)
 	}
 
-	return &result
-}
+	result.Recalculate()
 
-func calcSegmentLengths(path Path) {
-	var totalLength float32
-
-	for i := 0; i < len(path.Segments); i++ {
-		s := path.Segments[i]
-		chord := s[3].Sub(s[0]).Len()
-		controlNet := s[1].Sub(s[0]).Len() + s[2].Sub(s[1]).Len() + s[3].Sub(s[2]).Len()
-		path.Times[i] = (chord + controlNet) / 2
-		totalLength += path.Times[i]
-	}
-
-	for i := 0; i < len(path.Segments); i++ {
-		path.Times[i] = path.Times[i] / totalLength
-	}
+	return &result
 }
 
 func calcSingleSegment(points []mgl32.Vec2) [4]mgl32.Vec2 {
@@ -87,10 +60,10 @@ func calcSingleSegment(points []mgl32.Vec2) [4]mgl32.Vec2 {
 	}
 
 	segment := [4]mgl32.Vec2{
-		{points[0].X(), points[0].X()},
+		points[0],
 		control1,
 		control2,
-		{points[1].Y(), points[1].Y()},
+		points[1],
 	}
 
 	return segment
@@ -184,7 +157,13 @@ func calcInput(points []mgl32.Vec2) ([][3]float32, []mgl32.Vec2) {
 	input := []mgl32.Vec2{}
 
 	for i := 1; i < len(points)-1; i++ {
-		if i == 1 {
+		if i == 1 && i == len(points)-2 {
+			coefficients = append(coefficients, [3]float32{0, 4, 0})
+			input = append(input, mgl32.Vec2{
+				6.0*points[i].X() - points[i-1].X() - points[i+1].X(),
+				6.0*points[i].Y() - points[i-1].Y() - points[i+1].Y(),
+			})
+		} else if i == 1 {
 			coefficients = append(coefficients, [3]float32{0, 4, 1})
 			input = append(input, mgl32.Vec2{
 				6.0*points[i].X() - points[i-1].X(),
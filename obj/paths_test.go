@@ -0,0 +1,116 @@
+package obj
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+)
+
+const epsilon = 0.01
+
+// TestSingleSegmentPath : Test that a path between two points is the
+// straight line between them
+func TestSingleSegmentPath(t *testing.T) {
+	path := CreatePath([]int{10, 20, 110, 20})
+
+	var positionTests = []struct {
+		time     float32
+		position mgl32.Vec2
+	}{
+		{0, mgl32.Vec2{10, 20}},
+		{0.25, mgl32.Vec2{35, 20}},
+		{1, mgl32.Vec2{110, 20}},
+		{2, mgl32.Vec2{110, 20}},
+	}
+
+	for _, test := range positionTests {
+		position := path.PositionAt(test.time)
+		if !position.ApproxEqualThreshold(test.position, epsilon) {
+			t.Errorf("PositionAt(%v) was (%v) should be (%v)", test.time, position, test.position)
+		}
+	}
+
+	if x, y := path.GetPosition(2); x != 110 || y != 20 {
+		t.Errorf("GetPosition past the end was (%v, %v) should be (110, 20)", x, y)
+	}
+	if length := path.Length(); math.Abs(float64(length-100)) > epsilon {
+		t.Errorf("Length was (%v) should be (100)", length)
+	}
+}
+
+// TestArcLengthSpeed : Test that equal steps in time move equal distances
+// along a curved path
+func TestArcLengthSpeed(t *testing.T) {
+	path := CreatePath([]int{0, 0, 100, 100, 200, 0, 400, 50})
+
+	const steps = 50
+	step := path.Length() / steps
+	previous := path.PositionAt(0)
+
+	for i := 1; i <= steps; i++ {
+		position := path.PositionAt(float32(i) / steps)
+		distance := position.Sub(previous).Len()
+		if math.Abs(float64(distance-step)) > 0.01*float64(step) {
+			t.Errorf("Step %v moved (%v) should be (%v)", i, distance, step)
+		}
+		previous = position
+	}
+
+	var sum float32
+	for _, time := range path.Times {
+		sum += time
+	}
+	if math.Abs(float64(sum-1)) > epsilon {
+		t.Errorf("Times summed to (%v) should be (1)", sum)
+	}
+}
+
+// TestTangentNormal : Test the direction and normal along a straight path
+func TestTangentNormal(t *testing.T) {
+	path := CreatePath([]int{0, 0, 0, 100})
+
+	tangent := path.TangentAt(0.5)
+	if !tangent.ApproxEqualThreshold(mgl32.Vec2{0, 1}, epsilon) {
+		t.Errorf("Tangent was (%v) should be (%v)", tangent, mgl32.Vec2{0, 1})
+	}
+	normal := path.NormalAt(0)
+	if !normal.ApproxEqualThreshold(mgl32.Vec2{-1, 0}, epsilon) {
+		t.Errorf("Normal was (%v) should be (%v)", normal, mgl32.Vec2{-1, 0})
+	}
+}
+
+var followerTests = []struct {
+	name        string
+	mode        EndMode
+	x           float32
+	orientation float64
+}{
+	{"clamp", Clamp, 100, 0},
+	{"loop", Loop, 50, 0},
+	{"ping pong", PingPong, 50, math.Pi},
+}
+
+// TestFollower : Test the follower end modes after travelling one and a
+// half times the length of the path
+func TestFollower(t *testing.T) {
+	for _, test := range followerTests {
+		t.Run(test.name, func(t *testing.T) {
+			o := gologo.CreateObject(mgl32.Vec3{})
+			follower := FollowPath(o, CreatePath([]int{0, 0, 100, 0}), 100)
+			follower.Mode = test.mode
+
+			for i := 0; i < 3; i++ {
+				follower.Update(0.5)
+			}
+
+			if math.Abs(float64(o.Position.X()-test.x)) > epsilon {
+				t.Errorf("X was (%v) should be (%v)", o.Position.X(), test.x)
+			}
+			if math.Abs(o.Orientation-test.orientation) > epsilon {
+				t.Errorf("Orientation was (%v) should be (%v)", o.Orientation, test.orientation)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package obj
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+)
+
+// FillRule : Decides which regions enclosed by a set of outlines are filled
+type FillRule int
+
+const (
+	// EvenOdd : Fill regions crossed an odd number of times by a ray to
+	// infinity.  Holes are filled regardless of their direction.
+	EvenOdd FillRule = iota
+	// NonZero : Fill regions around which the outlines wind a non-zero
+	// number of times.  Holes must wind in the opposite direction to the
+	// outline around them.
+	NonZero
+)
+
+// PolygonFromPoints : Returns an object filling the outline through the
+// points, which may be concave or self-intersecting, with holes cut out
+// of it.  Regions are filled with the EvenOdd rule.
+func PolygonFromPoints(points []mgl32.Vec2, color mgl32.Vec4, holes ...[]mgl32.Vec2) *gologo.Object {
+	return PolygonFromContours(append([][]mgl32.Vec2{points}, holes...), EvenOdd, color)
+}
+
+// PolygonFromContours : Returns an object filling the regions enclosed by
+// the closed outlines according to the fill rule
+func PolygonFromContours(contours [][]mgl32.Vec2, rule FillRule, color mgl32.Vec4) *gologo.Object {
+	triangles := Triangulate(contours, rule)
+	if len(triangles) == 0 {
+		panic("Polygon must enclose a non-zero area")
+	}
+	return ColorMesh(triangles, color, "Polygon")
+}
+
+// Fill : Returns an object filling the regions enclosed by the paths, for
+// example the subpaths of a letter from ParseSubpaths.  Curves are
+// flattened to within tolerance.
+func Fill(paths []*Path, rule FillRule, tolerance float32, color mgl32.Vec4) *gologo.Object {
+	contours := [][]mgl32.Vec2{}
+	for _, path := range paths {
+		contours = append(contours, path.Flatten(tolerance))
+	}
+	return PolygonFromContours(contours, rule, color)
+}
+
+// fillEdge : An edge of an outline, directed upwards, with the winding
+// direction of the original edge
+type fillEdge struct {
+	x0, y0  float64
+	x1, y1  float64
+	winding int
+}
+
+func (e fillEdge) xAt(y float64) float64 {
+	return e.x0 + (e.x1-e.x0)*(y-e.y0)/(e.y1-e.y0)
+}
+
+// Triangulate : Returns triangles, as consecutive triples of points,
+// covering the regions enclosed by the closed outlines according to the
+// fill rule.
+//
+// The plane is cut into horizontal slabs at every vertex and every edge
+// crossing, so that no edges cross within a slab.  Within each slab the
+// edges are ordered from left to right and the trapezoids between them
+// which are inside according to the winding numbers are emitted.
+func Triangulate(contours [][]mgl32.Vec2, rule FillRule) []mgl32.Vec2 {
+	edges := []fillEdge{}
+	ys := []float64{}
+
+	for _, contour := range contours {
+		contour = removeDuplicates(contour)
+		for i := range contour {
+			a := contour[i]
+			b := contour[(i+1)%len(contour)]
+			ys = append(ys, float64(a.Y()))
+
+			if a.Y() == b.Y() {
+				continue
+			}
+
+			edge := fillEdge{
+				float64(a.X()), float64(a.Y()),
+				float64(b.X()), float64(b.Y()),
+				1,
+			}
+			if a.Y() > b.Y() {
+				edge = fillEdge{edge.x1, edge.y1, edge.x0, edge.y0, -1}
+			}
+			edges = append(edges, edge)
+		}
+	}
+
+	for i := 0; i < len(edges); i++ {
+		for j := i + 1; j < len(edges); j++ {
+			if y, ok := crossingY(edges[i], edges[j]); ok {
+				ys = append(ys, y)
+			}
+		}
+	}
+
+	ys = uniqueSorted(ys)
+	result := []mgl32.Vec2{}
+
+	for k := 0; k+1 < len(ys); k++ {
+		bottom, top := ys[k], ys[k+1]
+		middle := (bottom + top) / 2
+
+		spanning := []fillEdge{}
+		for _, e := range edges {
+			if e.y0 <= bottom && e.y1 >= top {
+				spanning = append(spanning, e)
+			}
+		}
+		sort.Slice(spanning, func(i, j int) bool {
+			return spanning[i].xAt(middle) < spanning[j].xAt(middle)
+		})
+
+		winding := 0
+		for i := 0; i+1 < len(spanning); i++ {
+			winding += spanning[i].winding
+			if !inside(winding, rule) {
+				continue
+			}
+
+			left, right := spanning[i], spanning[i+1]
+			result = appendTrapezoid(result,
+				left.xAt(bottom), right.xAt(bottom),
+				left.xAt(top), right.xAt(top),
+				bottom, top)
+		}
+	}
+
+	return result
+}
+
+func inside(winding int, rule FillRule) bool {
+	if rule == NonZero {
+		return winding != 0
+	}
+	return winding%2 != 0
+}
+
+// crossingY : Returns the height at which the edges cross strictly between
+// their end points
+func crossingY(a fillEdge, b fillEdge) (float64, bool) {
+	low := math.Max(a.y0, b.y0)
+	high := math.Min(a.y1, b.y1)
+	if low >= high {
+		return 0, false
+	}
+
+	// The horizontal distance between the edges changes linearly with y,
+	// so they cross where it changes sign
+	dLow := a.xAt(low) - b.xAt(low)
+	dHigh := a.xAt(high) - b.xAt(high)
+	if dLow*dHigh >= 0 {
+		return 0, false
+	}
+
+	return low + (high-low)*dLow/(dLow-dHigh), true
+}
+
+// appendTrapezoid : Appends the triangles of the trapezoid between two
+// horizontal lines, skipping triangles with no area
+func appendTrapezoid(
+	result []mgl32.Vec2,
+	bottomLeft float64,
+	bottomRight float64,
+	topLeft float64,
+	topRight float64,
+	bottom float64,
+	top float64,
+) []mgl32.Vec2 {
+	bl := mgl32.Vec2{float32(bottomLeft), float32(bottom)}
+	br := mgl32.Vec2{float32(bottomRight), float32(bottom)}
+	tl := mgl32.Vec2{float32(topLeft), float32(top)}
+	tr := mgl32.Vec2{float32(topRight), float32(top)}
+
+	if bl != br {
+		result = append(result, bl, br, tr)
+	}
+	if tl != tr {
+		result = append(result, bl, tr, tl)
+	}
+	return result
+}
+
+func uniqueSorted(values []float64) []float64 {
+	sort.Float64s(values)
+
+	result := []float64{}
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
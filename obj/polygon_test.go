@@ -0,0 +1,119 @@
+package obj
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+	"github.com/leedenison/gologo/gologotest"
+	"github.com/leedenison/gologo/render"
+)
+
+var (
+	outer    = []mgl32.Vec2{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	sameHole = []mgl32.Vec2{{25, 25}, {75, 25}, {75, 75}, {25, 75}}
+	oppoHole = []mgl32.Vec2{{25, 25}, {25, 75}, {75, 75}, {75, 25}}
+	lShape   = []mgl32.Vec2{{0, 0}, {100, 0}, {100, 50}, {50, 50}, {50, 100}, {0, 100}}
+)
+
+// pentagram : Returns the points of a self-intersecting five pointed star
+func pentagram() []mgl32.Vec2 {
+	result := []mgl32.Vec2{}
+	for i := 0; i < 5; i++ {
+		angle := math.Pi/2 + float64(i)*4*math.Pi/5
+		result = append(result, mgl32.Vec2{
+			float32(100 * math.Cos(angle)),
+			float32(100 * math.Sin(angle)),
+		})
+	}
+	return result
+}
+
+var triangulateTests = []struct {
+	name     string
+	contours [][]mgl32.Vec2
+	rule     FillRule
+	area     float64
+	inside   []mgl32.Vec2
+	outside  []mgl32.Vec2
+}{
+	{"concave", [][]mgl32.Vec2{lShape}, EvenOdd, 7500,
+		[]mgl32.Vec2{{25, 75}, {75, 25}}, []mgl32.Vec2{{75, 75}}},
+	{"even-odd hole", [][]mgl32.Vec2{outer, sameHole}, EvenOdd, 7500,
+		[]mgl32.Vec2{{10, 10}}, []mgl32.Vec2{{50, 50}}},
+	{"non-zero same direction", [][]mgl32.Vec2{outer, sameHole}, NonZero, 10000,
+		[]mgl32.Vec2{{10, 10}, {50, 50}}, nil},
+	{"non-zero opposite direction", [][]mgl32.Vec2{outer, oppoHole}, NonZero, 7500,
+		[]mgl32.Vec2{{10, 10}}, []mgl32.Vec2{{50, 50}}},
+	{"even-odd star", [][]mgl32.Vec2{pentagram()}, EvenOdd, 0,
+		[]mgl32.Vec2{{0, 80}}, []mgl32.Vec2{{0, 0}}},
+	{"non-zero star", [][]mgl32.Vec2{pentagram()}, NonZero, 0,
+		[]mgl32.Vec2{{0, 80}, {0, 0}}, nil},
+}
+
+// TestTriangulate : Test the filled area and points inside and outside
+// for concave, holed and self-intersecting outlines
+func TestTriangulate(t *testing.T) {
+	for _, test := range triangulateTests {
+		t.Run(test.name, func(t *testing.T) {
+			triangles := Triangulate(test.contours, test.rule)
+
+			if test.area > 0 {
+				if got := area(triangles); math.Abs(got-test.area) > 0.01 {
+					t.Errorf("Area was (%v) should be (%v)", got, test.area)
+				}
+			}
+
+			for _, p := range test.inside {
+				if !covers(triangles, p) {
+					t.Errorf("Point (%v) should be filled", p)
+				}
+			}
+			for _, p := range test.outside {
+				if covers(triangles, p) {
+					t.Errorf("Point (%v) should not be filled", p)
+				}
+			}
+		})
+	}
+}
+
+func covers(triangles []mgl32.Vec2, p mgl32.Vec2) bool {
+	for i := 0; i+2 < len(triangles); i += 3 {
+		if render.TriangleContains(triangles[i], triangles[i+1], triangles[i+2], p) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestPolygonsGolden : Test filled concave, holed and self-intersecting
+// polygons
+func TestPolygonsGolden(t *testing.T) {
+	g := gologotest.Init(160, 120)
+	defer g.Close()
+
+	ring := PolygonFromPoints(
+		[]mgl32.Vec2{{10, 10}, {70, 10}, {70, 70}, {10, 70}},
+		mgl32.Vec4{1.0, 0.5, 0.0, 1.0},
+		[]mgl32.Vec2{{25, 25}, {55, 25}, {40, 55}})
+
+	arrow := PolygonFromPoints(
+		[]mgl32.Vec2{{90, 20}, {150, 50}, {90, 80}, {110, 50}},
+		mgl32.Vec4{0.0, 0.5, 1.0, 1.0})
+
+	star := []mgl32.Vec2{}
+	for i := 0; i < 5; i++ {
+		angle := math.Pi/2 + float64(i)*4*math.Pi/5
+		star = append(star, mgl32.Vec2{
+			float32(40 + 25*math.Cos(angle)),
+			float32(95 + 25*math.Sin(angle)),
+		})
+	}
+	evenOdd := PolygonFromContours([][]mgl32.Vec2{star}, EvenOdd, mgl32.Vec4{1.0, 1.0, 0.0, 1.0})
+	nonZero := PolygonFromContours([][]mgl32.Vec2{star}, NonZero, mgl32.Vec4{1.0, 1.0, 0.0, 1.0})
+	nonZero.Translate(80, 0)
+
+	gologotest.AssertScene(t, g, "polygons", []*gologo.Object{ring, arrow, evenOdd, nonZero}, 0)
+}
@@ -0,0 +1,32 @@
+package obj
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+	"github.com/leedenison/gologo/gologotest"
+)
+
+// TestShapesGolden : Test that overlapping shapes are drawn in z-order
+// and match the golden image
+func TestShapesGolden(t *testing.T) {
+	g := gologotest.Init(160, 120)
+	defer g.Close()
+
+	background := Rectangle(
+		gologo.Rect{{20, 20}, {140, 100}},
+		mgl32.Vec4{0.0, 0.0, 1.0, 1.0})
+	background.SetZOrder(0)
+
+	hexagon := Polygon(mgl32.Vec2{80, 60}, 6, 30, mgl32.Vec4{1.0, 1.0, 0.0, 0.5})
+	hexagon.SetZOrder(2)
+
+	square := Rectangle(
+		gologo.Rect{{60, 40}, {100, 80}},
+		mgl32.Vec4{1.0, 0.0, 0.0, 1.0})
+	square.SetZOrder(1)
+	square.Rotate(0.3)
+
+	gologotest.AssertScene(t, g, "shapes", []*gologo.Object{hexagon, background, square}, 0)
+}
@@ -0,0 +1,92 @@
+package obj
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+	"github.com/leedenison/gologo/render"
+	"github.com/leedenison/gologo/time"
+)
+
+// Sprite : Returns an object drawing the image file at path centered on
+// the origin, one world unit per image pixel.  Textures are cached so
+// that each file is only loaded once, and images packed into an atlas
+// are drawn from the atlas.
+func Sprite(origin mgl32.Vec2, path string) *gologo.Object {
+	texture, bounds, err := render.CreateTextureRegion(path)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to create Sprite renderer: %v\n", err))
+	}
+
+	return spriteObject(origin, texture, bounds, bounds)
+}
+
+// SpriteRegion : Returns an object drawing the region of the image file at
+// path, in image pixels with the origin at the top left, centered on the
+// origin.  The region is limited to the bounds of the image.
+func SpriteRegion(origin mgl32.Vec2, path string, region image.Rectangle) *gologo.Object {
+	texture, bounds, err := render.CreateTextureRegion(path)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to create Sprite renderer: %v\n", err))
+	}
+
+	return spriteObject(origin, texture, bounds, region.Add(bounds.Min).Intersect(bounds))
+}
+
+// SetTint : Multiplies the colors of a sprite by the color
+func SetTint(object *gologo.Object, color mgl32.Vec4) {
+	spriteRenderer(object, "tint").MeshRenderer.Uniforms[render.UniformColor] = color
+}
+
+// SetOpacity : Sets the opacity of a sprite from 0, transparent, to 1
+func SetOpacity(object *gologo.Object, alpha float32) {
+	spriteRenderer(object, "set opacity of").MeshRenderer.Uniforms[render.UniformAlpha] = alpha
+}
+
+// SetFlip : Sets whether a sprite is mirrored horizontally and vertically
+func SetFlip(object *gologo.Object, flipX bool, flipY bool) {
+	renderer := spriteRenderer(object, "flip")
+	renderer.FlipX = flipX
+	renderer.FlipY = flipY
+}
+
+// SetSourceRect : Sets the region of the image, in image pixels, drawn by
+// a sprite.  The region is limited to the bounds of the image, and the
+// sprite is resized to it.  Panics if no part of the region is inside the
+// image.  Animated sprites replace the region with their next frame.
+func SetSourceRect(object *gologo.Object, region image.Rectangle) {
+	renderer := spriteRenderer(object, "set source of")
+	clipped := region.Add(renderer.Image.Min).Intersect(renderer.Image)
+	if clipped.Empty() {
+		panic(fmt.Sprintf("Failed to set source of sprite: empty sprite region: %v\n", region))
+	}
+	renderer.Region = clipped
+}
+
+func spriteObject(origin mgl32.Vec2, texture *render.GLTexture, bounds image.Rectangle, region image.Rectangle) *gologo.Object {
+	spriteRenderer, err := render.NewSpriteRenderer(texture, region)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to create Sprite renderer: %v\n", err))
+	}
+	spriteRenderer.Image = bounds
+
+	return &gologo.Object{
+		Position: mgl32.Vec3{origin[0], origin[1], 0.0},
+		Scale:    1.0,
+		Creation: time.GetTickTime(),
+		ZOrder:   0,
+		Renderer: spriteRenderer,
+	}
+}
+
+func spriteRenderer(object *gologo.Object, action string) *render.SpriteRenderer {
+	switch r := object.Renderer.(type) {
+	case *render.SpriteRenderer:
+		return r
+	case *render.AnimationRenderer:
+		return r.Sprite
+	}
+	panic(fmt.Sprintf("Failed to %v sprite: unsupported renderer %T\n", action, object.Renderer))
+}
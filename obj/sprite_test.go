@@ -0,0 +1,139 @@
+package obj
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+	"github.com/leedenison/gologo/atlas"
+	"github.com/leedenison/gologo/gologotest"
+	"github.com/leedenison/gologo/render"
+)
+
+// TestSpritesGolden : Test sprites loaded from an image file, with
+// source regions, flipping, tint, opacity and rotation
+func TestSpritesGolden(t *testing.T) {
+	g := gologotest.Init(160, 120)
+	defer g.Close()
+
+	path := filepath.Join(t.TempDir(), "sprite.png")
+	writeSpriteImage(t, path)
+
+	gologotest.AssertScene(t, g, "sprites", spriteScene(path), 0)
+}
+
+// TestAtlasSpritesGolden : Test that sprites of an image packed into an
+// atlas with other images draw the same as sprites of the image file
+func TestAtlasSpritesGolden(t *testing.T) {
+	g := gologotest.Init(160, 120)
+	defer g.Close()
+
+	dir := t.TempDir()
+	paths := []string{filepath.Join(dir, "other.png"), filepath.Join(dir, "sprite.png")}
+	writeSpriteImage(t, paths[0])
+	writeSpriteImage(t, paths[1])
+	if err := render.LoadAtlas(paths, atlas.Options{Padding: 2, Extrude: 1}); err != nil {
+		t.Fatalf("LoadAtlas failed: %v", err)
+	}
+
+	// Texture co-ordinates within the larger atlas round differently
+	gologotest.AssertScene(t, g, "sprites", spriteScene(paths[1]), 1)
+}
+
+// TestSpriteRegionBounds : Test that sprite regions of an image packed
+// into an atlas do not extend into the neighbouring images, and that
+// regions entirely outside the image are rejected
+func TestSpriteRegionBounds(t *testing.T) {
+	g := gologotest.Init(160, 120)
+	defer g.Close()
+
+	dir := t.TempDir()
+	paths := []string{filepath.Join(dir, "a.png"), filepath.Join(dir, "b.png")}
+	writeSpriteImage(t, paths[0])
+	writeSpriteImage(t, paths[1])
+	if err := render.LoadAtlas(paths, atlas.Options{}); err != nil {
+		t.Fatalf("LoadAtlas failed: %v", err)
+	}
+	_, bounds, _ := render.CreateTextureRegion(paths[1])
+
+	sprite := SpriteRegion(mgl32.Vec2{}, paths[1], image.Rect(-8, 16, 40, 40))
+	expected := image.Rect(0, 16, 32, 24).Add(bounds.Min)
+	if region := sprite.Renderer.(*render.SpriteRenderer).Region; region != expected {
+		t.Errorf("Region was (%v) should be (%v)", region, expected)
+	}
+
+	SetSourceRect(sprite, image.Rect(24, -4, 64, 8))
+	expected = image.Rect(24, 0, 32, 8).Add(bounds.Min)
+	if region := sprite.Renderer.(*render.SpriteRenderer).Region; region != expected {
+		t.Errorf("Region after SetSourceRect was (%v) should be (%v)", region, expected)
+	}
+
+	for _, region := range []image.Rectangle{
+		image.Rect(40, 0, 48, 8),
+		image.Rect(8, 8, 8, 16),
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("SetSourceRect with (%v) should panic", region)
+				}
+			}()
+			SetSourceRect(sprite, region)
+		}()
+	}
+	expected = image.Rect(24, 0, 32, 8).Add(bounds.Min)
+	if region := sprite.Renderer.(*render.SpriteRenderer).Region; region != expected {
+		t.Errorf("Region after empty SetSourceRect was (%v) should be (%v)", region, expected)
+	}
+}
+
+// spriteScene : Returns sprites of the image file at path with source
+// regions, flipping, tint, opacity and rotation
+func spriteScene(path string) []*gologo.Object {
+	whole := Sprite(mgl32.Vec2{30, 85}, path)
+	flipped := Sprite(mgl32.Vec2{80, 85}, path)
+	SetFlip(flipped, true, false)
+	tinted := Sprite(mgl32.Vec2{130, 85}, path)
+	SetTint(tinted, mgl32.Vec4{1.0, 0.5, 0.5, 1.0})
+	region := SpriteRegion(mgl32.Vec2{30, 30}, path, image.Rect(0, 0, 16, 16))
+	faded := Sprite(mgl32.Vec2{80, 30}, path)
+	SetOpacity(faded, 0.4)
+	rotated := Sprite(mgl32.Vec2{130, 30}, path)
+	SetFlip(rotated, false, true)
+	rotated.Rotate(0.5)
+
+	return []*gologo.Object{whole, flipped, tinted, region, faded, rotated}
+}
+
+// writeSpriteImage : Writes a 32x24 PNG with a differently colored
+// quadrant in each corner and a white diagonal, so that flips are visible
+func writeSpriteImage(t *testing.T, path string) {
+	rgba := image.NewRGBA(image.Rect(0, 0, 32, 24))
+	quadrants := []color.RGBA{
+		{255, 0, 0, 255}, {0, 255, 0, 255},
+		{0, 0, 255, 255}, {255, 255, 0, 255},
+	}
+	for y := 0; y < 24; y++ {
+		for x := 0; x < 32; x++ {
+			c := quadrants[2*(y/12)+x/16]
+			if x*3 == y*4 || x*3 == y*4+1 || x*3 == y*4+2 {
+				c = color.RGBA{255, 255, 255, 255}
+			}
+			rgba.SetRGBA(x, y, c)
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	defer file.Close()
+	if err := png.Encode(file, rgba); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+}
@@ -0,0 +1,424 @@
+package obj
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+)
+
+// JoinStyle : How the corners between stroked line segments are drawn
+type JoinStyle int
+
+const (
+	MiterJoin JoinStyle = iota
+	RoundJoin
+	BevelJoin
+)
+
+// CapStyle : How the ends of open stroked lines are drawn
+type CapStyle int
+
+const (
+	ButtCap CapStyle = iota
+	RoundCap
+	SquareCap
+)
+
+// StrokeStyle : Width is the line width, 1 if zero.  Miter joins longer
+// than MiterLimit times the width are drawn as bevels; the limit is 4 if
+// zero.  Dash alternates the lengths of drawn and skipped parts of the
+// line, starting DashOffset along it; the line is solid if Dash is empty.
+// Closed joins the end of the line back to its start.  Tolerance is the
+// maximum distance of flattened curves and round joins and caps from the
+// true curve, 0.25 if zero.
+//
+// Overlapping parts of the stroke, such as the inside of sharp corners,
+// are drawn twice, which shows with translucent colors.
+type StrokeStyle struct {
+	Width      float32
+	Join       JoinStyle
+	Cap        CapStyle
+	MiterLimit float32
+	Dash       []float32
+	DashOffset float32
+	Closed     bool
+	Tolerance  float32
+}
+
+const (
+	defaultMiterLimit = 4
+	defaultTolerance  = 0.25
+	maxFlattenDepth   = 16
+)
+
+// Polyline : Returns an object drawing the line through the points
+func Polyline(points []mgl32.Vec2, style StrokeStyle, color mgl32.Vec4) *gologo.Object {
+	triangles := strokeTriangles(points, style)
+	if len(triangles) == 0 {
+		panic("Polyline must have at least two distinct points")
+	}
+	return ColorMesh(triangles, color, "Polyline")
+}
+
+// Stroke : Returns an object drawing the outline of the path.  Curves are
+// flattened to within style.Tolerance.  The stroke is closed if the path
+// or the style is Closed.
+func Stroke(path *Path, style StrokeStyle, color mgl32.Vec4) *gologo.Object {
+	style.Closed = style.Closed || path.Closed
+	triangles := strokeTriangles(path.Flatten(tolerance(style)), style)
+	if len(triangles) == 0 {
+		panic("Stroke path must have non-zero length")
+	}
+	return ColorMesh(triangles, color, "Stroke")
+}
+
+// Flatten : Returns points along the path such that straight lines between
+// them are within tolerance of the curve.  Segments are subdivided
+// adaptively, so straight parts of the path produce few points.
+func (p *Path) Flatten(tolerance float32) []mgl32.Vec2 {
+	result := []mgl32.Vec2{}
+
+	for i, s := range p.Segments {
+		if i == 0 || s[0] != p.Segments[i-1][3] {
+			result = append(result, s[0])
+		}
+		result = flattenSegment(s, tolerance, 0, result)
+	}
+
+	return result
+}
+
+// flattenSegment : Appends the points of the flattened segment, excluding
+// its start point
+func flattenSegment(s [4]mgl32.Vec2, tolerance float32, depth int, result []mgl32.Vec2) []mgl32.Vec2 {
+	if depth >= maxFlattenDepth || isFlat(s, tolerance) {
+		return append(result, s[3])
+	}
+
+	// Split in half with de Casteljau's algorithm
+	ab := s[0].Add(s[1]).Mul(0.5)
+	bc := s[1].Add(s[2]).Mul(0.5)
+	cd := s[2].Add(s[3]).Mul(0.5)
+	abc := ab.Add(bc).Mul(0.5)
+	bcd := bc.Add(cd).Mul(0.5)
+	mid := abc.Add(bcd).Mul(0.5)
+
+	result = flattenSegment([4]mgl32.Vec2{s[0], ab, abc, mid}, tolerance, depth+1, result)
+	return flattenSegment([4]mgl32.Vec2{mid, bcd, cd, s[3]}, tolerance, depth+1, result)
+}
+
+// isFlat : Returns true if both control points are within tolerance of
+// the chord, which bounds the distance of the curve from it
+func isFlat(s [4]mgl32.Vec2, tolerance float32) bool {
+	return distanceToSegment(s[1], s[0], s[3]) <= tolerance &&
+		distanceToSegment(s[2], s[0], s[3]) <= tolerance
+}
+
+func distanceToSegment(p mgl32.Vec2, a mgl32.Vec2, b mgl32.Vec2) float32 {
+	ab := b.Sub(a)
+	lengthSq := ab.Dot(ab)
+	if lengthSq == 0 {
+		return p.Sub(a).Len()
+	}
+
+	t := clampFloat32(p.Sub(a).Dot(ab)/lengthSq, 0, 1)
+	return p.Sub(a.Add(ab.Mul(t))).Len()
+}
+
+// withDefaults : Returns the style with defaults in place of zero values
+func (style StrokeStyle) withDefaults() StrokeStyle {
+	if style.Width <= 0 {
+		style.Width = 1
+	}
+	if style.MiterLimit <= 0 {
+		style.MiterLimit = defaultMiterLimit
+	}
+	style.Tolerance = tolerance(style)
+	return style
+}
+
+func tolerance(style StrokeStyle) float32 {
+	if style.Tolerance <= 0 {
+		return defaultTolerance
+	}
+	return style.Tolerance
+}
+
+/////////////////////////////////////////////////////////////
+// Tessellation
+//
+
+// strokeTriangles : Returns the triangles, as consecutive triples of
+// points, covering the stroked line through the points
+func strokeTriangles(points []mgl32.Vec2, style StrokeStyle) []mgl32.Vec2 {
+	style = style.withDefaults()
+
+	points = removeDuplicates(points)
+	closed := style.Closed && len(points) > 2
+	if closed && points[0] == points[len(points)-1] {
+		points = points[:len(points)-1]
+	}
+	if len(points) < 2 {
+		return nil
+	}
+
+	if len(style.Dash) == 0 {
+		return strokePolyline(points, closed, style)
+	}
+
+	result := []mgl32.Vec2{}
+	for _, dash := range dashPolyline(points, closed, style) {
+		result = append(result, strokePolyline(dash, false, style)...)
+	}
+	return result
+}
+
+// strokePolyline : Returns the triangles for a quad along each line
+// segment, a join at each corner and a cap at each end if not closed
+func strokePolyline(points []mgl32.Vec2, closed bool, style StrokeStyle) []mgl32.Vec2 {
+	half := style.Width / 2
+	result := []mgl32.Vec2{}
+
+	count := len(points) - 1
+	if closed {
+		count = len(points)
+	}
+
+	for i := 0; i < count; i++ {
+		a := points[i]
+		b := points[(i+1)%len(points)]
+		n := normal(a, b).Mul(half)
+
+		result = append(result,
+			a.Add(n), a.Sub(n), b.Sub(n),
+			a.Add(n), b.Sub(n), b.Add(n))
+	}
+
+	for i := 0; i < len(points); i++ {
+		if !closed && (i == 0 || i == len(points)-1) {
+			continue
+		}
+
+		previous := points[(i+len(points)-1)%len(points)]
+		next := points[(i+1)%len(points)]
+		result = append(result, joinTriangles(previous, points[i], next, style)...)
+	}
+
+	if !closed {
+		result = append(result, capTriangles(points[1], points[0], style)...)
+		result = append(result, capTriangles(points[len(points)-2], points[len(points)-1], style)...)
+	}
+
+	return result
+}
+
+// joinTriangles : Returns the triangles filling the gap on the outside of
+// the corner at p between the segments from a and to b
+func joinTriangles(a mgl32.Vec2, p mgl32.Vec2, b mgl32.Vec2, style StrokeStyle) []mgl32.Vec2 {
+	half := style.Width / 2
+	d0 := p.Sub(a).Normalize()
+	d1 := b.Sub(p).Normalize()
+
+	turn := d0.X()*d1.Y() - d0.Y()*d1.X()
+	if math.Abs(float64(turn)) < 1e-6 && d0.Dot(d1) > 0 {
+		// Straight on, so there is no gap
+		return nil
+	}
+
+	// The outside of the corner is to the right of a left turn
+	side := float32(1)
+	if turn > 0 {
+		side = -1
+	}
+	n0 := mgl32.Vec2{-d0.Y(), d0.X()}.Mul(half * side)
+	n1 := mgl32.Vec2{-d1.Y(), d1.X()}.Mul(half * side)
+
+	switch style.Join {
+	case RoundJoin:
+		return fanTriangles(p, n0, n1, half, turn < 0, style.Tolerance)
+	case MiterJoin:
+		// The miter point is along the bisector of the two normals
+		bisector := n0.Add(n1)
+		if bisector.Len() > 0 {
+			cos := n0.Normalize().Dot(bisector.Normalize())
+			if cos > 0 && 1/cos <= style.MiterLimit {
+				miter := p.Add(bisector.Normalize().Mul(half / cos))
+				return []mgl32.Vec2{
+					p, p.Add(n0), miter,
+					p, miter, p.Add(n1),
+				}
+			}
+		}
+	}
+
+	return []mgl32.Vec2{p, p.Add(n0), p.Add(n1)}
+}
+
+// capTriangles : Returns the triangles for the cap at the end p of the
+// segment from a
+func capTriangles(a mgl32.Vec2, p mgl32.Vec2, style StrokeStyle) []mgl32.Vec2 {
+	half := style.Width / 2
+	d := p.Sub(a).Normalize().Mul(half)
+	n := mgl32.Vec2{-d.Y(), d.X()}
+
+	switch style.Cap {
+	case SquareCap:
+		return []mgl32.Vec2{
+			p.Add(n), p.Sub(n), p.Sub(n).Add(d),
+			p.Add(n), p.Sub(n).Add(d), p.Add(n).Add(d),
+		}
+	case RoundCap:
+		return fanTriangles(p, n, n.Mul(-1), half, true, style.Tolerance)
+	}
+
+	return nil
+}
+
+// fanTriangles : Returns a fan of triangles around center from the offset
+// from to the offset to, turning clockwise if clockwise is set.  The number
+// of triangles keeps the arc within tolerance of a true circle.
+func fanTriangles(
+	center mgl32.Vec2,
+	from mgl32.Vec2,
+	to mgl32.Vec2,
+	radius float32,
+	clockwise bool,
+	tolerance float32,
+) []mgl32.Vec2 {
+	start := math.Atan2(float64(from.Y()), float64(from.X()))
+	end := math.Atan2(float64(to.Y()), float64(to.X()))
+
+	sweep := end - start
+	if clockwise && sweep > 0 {
+		sweep -= 2 * math.Pi
+	} else if !clockwise && sweep < 0 {
+		sweep += 2 * math.Pi
+	}
+
+	count := arcSegmentCount(radius, math.Abs(sweep), tolerance)
+	result := []mgl32.Vec2{}
+	previous := center.Add(from)
+
+	for i := 1; i <= count; i++ {
+		angle := start + sweep*float64(i)/float64(count)
+		point := center.Add(mgl32.Vec2{
+			radius * float32(math.Cos(angle)),
+			radius * float32(math.Sin(angle)),
+		})
+		if i == count {
+			point = center.Add(to)
+		}
+
+		result = append(result, center, previous, point)
+		previous = point
+	}
+
+	return result
+}
+
+// arcSegmentCount : Returns the number of straight lines needed to draw an
+// arc of the radius through sweep radians within tolerance of the true arc
+func arcSegmentCount(radius float32, sweep float64, tolerance float32) int {
+	if radius <= tolerance {
+		return int(math.Max(1, math.Ceil(sweep/(math.Pi/2))))
+	}
+
+	step := 2 * math.Acos(1-float64(tolerance/radius))
+	return int(math.Max(1, math.Ceil(sweep/step)))
+}
+
+// dashPolyline : Splits the line into the dashes which are drawn
+func dashPolyline(points []mgl32.Vec2, closed bool, style StrokeStyle) [][]mgl32.Vec2 {
+	pattern := style.Dash
+	if len(pattern)%2 == 1 {
+		pattern = append(append([]float32{}, pattern...), pattern...)
+	}
+
+	var period float32
+	for _, length := range pattern {
+		period += length
+	}
+	if period <= 0 {
+		return [][]mgl32.Vec2{points}
+	}
+
+	if closed {
+		points = append(append([]mgl32.Vec2{}, points...), points[0])
+	}
+
+	// Find the position in the pattern at the start of the line
+	offset := float32(math.Mod(float64(style.DashOffset), float64(period)))
+	if offset < 0 {
+		offset += period
+	}
+	index := 0
+	for offset >= pattern[index] {
+		offset -= pattern[index]
+		index = (index + 1) % len(pattern)
+	}
+	remaining := pattern[index] - offset
+
+	result := [][]mgl32.Vec2{}
+	var current []mgl32.Vec2
+	if index%2 == 0 {
+		current = []mgl32.Vec2{points[0]}
+	}
+
+	for i := 0; i+1 < len(points); i++ {
+		a := points[i]
+		b := points[i+1]
+		length := b.Sub(a).Len()
+		position := float32(0)
+
+		for length-position > remaining {
+			position += remaining
+			point := a.Add(b.Sub(a).Mul(position / length))
+
+			if index%2 == 0 {
+				current = append(current, point)
+				result = append(result, current)
+				current = nil
+			} else {
+				current = []mgl32.Vec2{point}
+			}
+
+			index = (index + 1) % len(pattern)
+			remaining = pattern[index]
+		}
+
+		remaining -= length - position
+		if current != nil {
+			current = append(current, b)
+		}
+	}
+
+	if len(current) > 1 {
+		result = append(result, current)
+	}
+
+	// Discard zero length dashes
+	dashes := [][]mgl32.Vec2{}
+	for _, dash := range result {
+		if dash = removeDuplicates(dash); len(dash) > 1 {
+			dashes = append(dashes, dash)
+		}
+	}
+	return dashes
+}
+
+// normal : Returns the unit normal to the left of the direction from a to b
+func normal(a mgl32.Vec2, b mgl32.Vec2) mgl32.Vec2 {
+	d := b.Sub(a).Normalize()
+	return mgl32.Vec2{-d.Y(), d.X()}
+}
+
+func removeDuplicates(points []mgl32.Vec2) []mgl32.Vec2 {
+	result := []mgl32.Vec2{}
+	for i, p := range points {
+		if i == 0 || p != points[i-1] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
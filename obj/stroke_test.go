@@ -0,0 +1,136 @@
+package obj
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+	"github.com/leedenison/gologo/gologotest"
+)
+
+// area : Returns the total area of the triangles
+func area(triangles []mgl32.Vec2) float64 {
+	var total float64
+	for i := 0; i+2 < len(triangles); i += 3 {
+		a, b, c := triangles[i], triangles[i+1], triangles[i+2]
+		total += math.Abs(float64(b.Sub(a).X()*c.Sub(a).Y()-b.Sub(a).Y()*c.Sub(a).X())) / 2
+	}
+	return total
+}
+
+var strokeAreaTests = []struct {
+	name  string
+	style StrokeStyle
+	area  float64
+}{
+	{"butt", StrokeStyle{Width: 10}, 1000},
+	{"square", StrokeStyle{Width: 10, Cap: SquareCap}, 1100},
+	{"round", StrokeStyle{Width: 10, Cap: RoundCap}, 1000 + 25*math.Pi},
+	{"dashed", StrokeStyle{Width: 10, Dash: []float32{10, 10}}, 500},
+	{"dash offset", StrokeStyle{Width: 10, Dash: []float32{10, 10}, DashOffset: 5}, 500},
+	{"odd dash", StrokeStyle{Width: 10, Dash: []float32{20}}, 600},
+}
+
+// TestStrokeArea : Test the area covered by a straight stroke with each
+// cap and dash style
+func TestStrokeArea(t *testing.T) {
+	points := []mgl32.Vec2{{0, 0}, {100, 0}}
+
+	for _, test := range strokeAreaTests {
+		t.Run(test.name, func(t *testing.T) {
+			got := area(strokeTriangles(points, test.style))
+			if math.Abs(got-test.area) > 0.01*test.area {
+				t.Errorf("Area was (%v) should be (%v)", got, test.area)
+			}
+		})
+	}
+}
+
+var joinTests = []struct {
+	name  string
+	style StrokeStyle
+	area  float64
+}{
+	{"bevel", StrokeStyle{Width: 10, Join: BevelJoin}, 12.5},
+	{"miter", StrokeStyle{Width: 10, Join: MiterJoin}, 25},
+	{"miter limit", StrokeStyle{Width: 10, Join: MiterJoin, MiterLimit: 1.2}, 12.5},
+	{"round", StrokeStyle{Width: 10, Join: RoundJoin, Tolerance: 0.01}, 25 * math.Pi / 4},
+}
+
+// TestJoins : Test the area filled outside a right angle corner
+func TestJoins(t *testing.T) {
+	for _, test := range joinTests {
+		t.Run(test.name, func(t *testing.T) {
+			got := area(joinTriangles(mgl32.Vec2{0, 0}, mgl32.Vec2{100, 0}, mgl32.Vec2{100, 100}, test.style.withDefaults()))
+			if math.Abs(got-test.area) > 0.02*test.area {
+				t.Errorf("Area was (%v) should be (%v)", got, test.area)
+			}
+		})
+	}
+}
+
+// TestClosedStroke : Test that a closed square has no caps and a join at
+// every corner
+func TestClosedStroke(t *testing.T) {
+	square := []mgl32.Vec2{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	got := area(strokeTriangles(square, StrokeStyle{Width: 10, Closed: true}))
+
+	// Four sides plus four mitered outer corners
+	expected := 4*1000.0 + 4*25.0
+	if math.Abs(got-expected) > 1 {
+		t.Errorf("Area was (%v) should be (%v)", got, expected)
+	}
+}
+
+// TestFlatten : Test that flattening is adaptive and within tolerance
+func TestFlatten(t *testing.T) {
+	line := &Path{Segments: [][4]mgl32.Vec2{lineSegment(mgl32.Vec2{0, 0}, mgl32.Vec2{100, 0})}}
+	if points := line.Flatten(0.25); len(points) != 2 {
+		t.Errorf("Straight line flattened to (%v) points should be (2)", len(points))
+	}
+
+	curve, _ := ParsePathData("M 0 0 A 100 100 0 0 1 200 0")
+	coarse := curve.Flatten(1)
+	fine := curve.Flatten(0.1)
+	if len(fine) <= len(coarse) {
+		t.Errorf("Fine points (%v) should be more than coarse points (%v)", len(fine), len(coarse))
+	}
+
+	for i := 0; i+1 < len(fine); i++ {
+		mid := fine[i].Add(fine[i+1]).Mul(0.5)
+		if d := math.Abs(float64(mid.Sub(mgl32.Vec2{100, 0}).Len()) - 100); d > 0.2 {
+			t.Errorf("Chord %v was (%v) from the curve should be within (0.1)", i, d)
+		}
+	}
+}
+
+// TestStrokesGolden : Test stroked lines with each join, cap and dash style
+func TestStrokesGolden(t *testing.T) {
+	g := gologotest.Init(160, 120)
+	defer g.Close()
+
+	zigzag := []mgl32.Vec2{{0, 0}, {20, 20}, {40, 0}}
+	objects := []*gologo.Object{}
+
+	for i, join := range []JoinStyle{MiterJoin, RoundJoin, BevelJoin} {
+		offset := mgl32.Vec2{float32(10 + 50*i), 80}
+		points := []mgl32.Vec2{}
+		for _, p := range zigzag {
+			points = append(points, p.Add(offset))
+		}
+		objects = append(objects, Polyline(points,
+			StrokeStyle{Width: 8, Join: join, Cap: CapStyle(i)},
+			mgl32.Vec4{1.0, 1.0, 1.0, 1.0}))
+	}
+
+	curve, err := ParsePathData("M 20 20 C 40 70, 120 -20, 140 40")
+	if err != nil {
+		t.Fatalf("ParsePathData failed: %v", err)
+	}
+	objects = append(objects, Stroke(curve,
+		StrokeStyle{Width: 6, Cap: RoundCap, Dash: []float32{15, 8}},
+		mgl32.Vec4{0.0, 1.0, 0.0, 1.0}))
+
+	gologotest.AssertScene(t, g, "strokes", objects, 0)
+}
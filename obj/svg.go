@@ -0,0 +1,499 @@
+package obj
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// ParsePathData : Returns the path described by SVG path data, for example
+// "M 10 10 C 20 40, 60 40, 70 10 Z".  The M, L, H, V, C, S, Q, T, A and Z
+// commands are supported in both absolute and relative forms.  Lines,
+// quadratics and arcs are converted to cubic segments.  Co-ordinates are
+// used unchanged, so drawings from tools with Y increasing downwards will
+// appear upside down unless flipped.
+//
+// Returns an error if the data contains more than one subpath; use
+// ParseSubpaths for those.
+func ParsePathData(d string) (*Path, error) {
+	paths, err := ParseSubpaths(d)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) != 1 {
+		return nil, fmt.Errorf("path data has %v subpaths, expected 1", len(paths))
+	}
+	return paths[0], nil
+}
+
+// ParseSubpaths : Returns a path for each subpath in SVG path data.  Each
+// moveto command after the first starts a new subpath.  Subpaths without
+// any segments, such as a moveto followed by another moveto, are omitted.
+func ParseSubpaths(d string) ([]*Path, error) {
+	parser := &svgParser{data: d}
+	if err := parser.parse(); err != nil {
+		return nil, fmt.Errorf("failed to parse path data: %v", err)
+	}
+
+	paths := []*Path{}
+	for _, path := range parser.paths {
+		if len(path.Segments) == 0 {
+			continue
+		}
+		path.Recalculate()
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// SVGPathData : Returns SVG path data describing the path with absolute
+// moveto and cubic commands, closed with Z if the path is Closed
+func (p *Path) SVGPathData() string {
+	var b strings.Builder
+
+	for i, s := range p.Segments {
+		if i == 0 || s[0] != p.Segments[i-1][3] {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			fmt.Fprintf(&b, "M %v %v", formatFloat(s[0].X()), formatFloat(s[0].Y()))
+		}
+
+		fmt.Fprintf(&b, " C %v %v, %v %v, %v %v",
+			formatFloat(s[1].X()), formatFloat(s[1].Y()),
+			formatFloat(s[2].X()), formatFloat(s[2].Y()),
+			formatFloat(s[3].X()), formatFloat(s[3].Y()))
+	}
+
+	if p.Closed && len(p.Segments) > 0 {
+		b.WriteString(" Z")
+	}
+
+	return b.String()
+}
+
+func formatFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
+
+// lineSegment : Returns the cubic segment for the straight line from a to b
+func lineSegment(a mgl32.Vec2, b mgl32.Vec2) [4]mgl32.Vec2 {
+	return [4]mgl32.Vec2{
+		a,
+		a.Add(b.Sub(a).Mul(1.0 / 3)),
+		a.Add(b.Sub(a).Mul(2.0 / 3)),
+		b,
+	}
+}
+
+// quadSegment : Returns the cubic segment for the quadratic curve from a
+// to b with control point q
+func quadSegment(a mgl32.Vec2, q mgl32.Vec2, b mgl32.Vec2) [4]mgl32.Vec2 {
+	return [4]mgl32.Vec2{
+		a,
+		a.Add(q.Sub(a).Mul(2.0 / 3)),
+		b.Add(q.Sub(b).Mul(2.0 / 3)),
+		b,
+	}
+}
+
+// arcSegments : Returns cubic segments approximating the SVG elliptical arc
+// from a to b, converting from endpoint to center parameterization.  Each
+// segment spans at most a quarter turn.
+func arcSegments(
+	a mgl32.Vec2,
+	rx float64,
+	ry float64,
+	rotation float64,
+	largeArc bool,
+	sweep bool,
+	b mgl32.Vec2,
+) [][4]mgl32.Vec2 {
+	if a == b {
+		return nil
+	}
+
+	rx = math.Abs(rx)
+	ry = math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		return [][4]mgl32.Vec2{lineSegment(a, b)}
+	}
+
+	phi := rotation * math.Pi / 180
+	cosPhi, sinPhi := math.Cos(phi), math.Sin(phi)
+	x1, y1 := float64(a.X()), float64(a.Y())
+	x2, y2 := float64(b.X()), float64(b.Y())
+
+	dx, dy := (x1-x2)/2, (y1-y2)/2
+	x1p := cosPhi*dx + sinPhi*dy
+	y1p := -sinPhi*dx + cosPhi*dy
+
+	// Scale up radii which are too small to span the end points
+	if lambda := x1p*x1p/(rx*rx) + y1p*y1p/(ry*ry); lambda > 1 {
+		rx *= math.Sqrt(lambda)
+		ry *= math.Sqrt(lambda)
+	}
+
+	numerator := rx*rx*ry*ry - rx*rx*y1p*y1p - ry*ry*x1p*x1p
+	denominator := rx*rx*y1p*y1p + ry*ry*x1p*x1p
+	coef := math.Sqrt(math.Max(0, numerator/denominator))
+	if largeArc == sweep {
+		coef = -coef
+	}
+
+	cxp := coef * rx * y1p / ry
+	cyp := -coef * ry * x1p / rx
+	cx := cosPhi*cxp - sinPhi*cyp + (x1+x2)/2
+	cy := sinPhi*cxp + cosPhi*cyp + (y1+y2)/2
+
+	theta1 := math.Atan2((y1p-cyp)/ry, (x1p-cxp)/rx)
+	theta2 := math.Atan2((-y1p-cyp)/ry, (-x1p-cxp)/rx)
+	delta := theta2 - theta1
+	if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	} else if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	}
+
+	point := func(theta float64) mgl32.Vec2 {
+		return mgl32.Vec2{
+			float32(cx + rx*cosPhi*math.Cos(theta) - ry*sinPhi*math.Sin(theta)),
+			float32(cy + rx*sinPhi*math.Cos(theta) + ry*cosPhi*math.Sin(theta)),
+		}
+	}
+	derivative := func(theta float64) mgl32.Vec2 {
+		return mgl32.Vec2{
+			float32(-rx*cosPhi*math.Sin(theta) - ry*sinPhi*math.Cos(theta)),
+			float32(-rx*sinPhi*math.Sin(theta) + ry*cosPhi*math.Cos(theta)),
+		}
+	}
+
+	count := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(count)
+	k := float32(4.0 / 3.0 * math.Tan(step/4))
+
+	result := [][4]mgl32.Vec2{}
+	start := a
+	for i := 0; i < count; i++ {
+		t1 := theta1 + float64(i)*step
+		t2 := t1 + step
+
+		end := point(t2)
+		if i == count-1 {
+			end = b
+		}
+
+		result = append(result, [4]mgl32.Vec2{
+			start,
+			start.Add(derivative(t1).Mul(k)),
+			end.Sub(derivative(t2).Mul(k)),
+			end,
+		})
+		start = end
+	}
+
+	return result
+}
+
+/////////////////////////////////////////////////////////////
+// Parser
+//
+
+type svgParser struct {
+	data  string
+	pos   int
+	paths []*Path
+
+	current   mgl32.Vec2
+	start     mgl32.Vec2
+	command   byte
+	lastCubic mgl32.Vec2
+	lastQuad  mgl32.Vec2
+}
+
+func (p *svgParser) parse() error {
+	for {
+		p.skipSeparators()
+		if p.pos >= len(p.data) {
+			return nil
+		}
+
+		c := p.data[p.pos]
+		if isCommand(c) {
+			p.pos++
+		} else if p.command == 0 || p.command == 'Z' || p.command == 'z' {
+			return fmt.Errorf("expected command at %v", p.pos)
+		} else {
+			// Repeated arguments repeat the previous command, except
+			// that a moveto is followed by implicit linetos
+			c = p.command
+			if c == 'M' {
+				c = 'L'
+			} else if c == 'm' {
+				c = 'l'
+			}
+		}
+
+		if p.command == 0 && c != 'M' && c != 'm' {
+			return fmt.Errorf("path data must start with a moveto")
+		}
+
+		if err := p.execute(c); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *svgParser) execute(c byte) error {
+	relative := c >= 'a'
+	origin := mgl32.Vec2{}
+	if relative {
+		origin = p.current
+	}
+
+	previous := p.command
+	p.command = c
+
+	switch c {
+	case 'M', 'm':
+		point, err := p.point(origin)
+		if err != nil {
+			return err
+		}
+		p.paths = append(p.paths, &Path{})
+		p.current = point
+		p.start = point
+
+	case 'L', 'l':
+		point, err := p.point(origin)
+		if err != nil {
+			return err
+		}
+		p.add(lineSegment(p.current, point))
+
+	case 'H', 'h':
+		x, err := p.number()
+		if err != nil {
+			return err
+		}
+		p.add(lineSegment(p.current, mgl32.Vec2{x + origin.X(), p.current.Y()}))
+
+	case 'V', 'v':
+		y, err := p.number()
+		if err != nil {
+			return err
+		}
+		p.add(lineSegment(p.current, mgl32.Vec2{p.current.X(), y + origin.Y()}))
+
+	case 'C', 'c', 'S', 's':
+		var control1 mgl32.Vec2
+		if c == 'C' || c == 'c' {
+			point, err := p.point(origin)
+			if err != nil {
+				return err
+			}
+			control1 = point
+		} else {
+			control1 = p.current
+			if strings.IndexByte("CcSs", previous) >= 0 {
+				control1 = p.current.Mul(2).Sub(p.lastCubic)
+			}
+		}
+
+		points, err := p.points(origin, 2)
+		if err != nil {
+			return err
+		}
+		p.add([4]mgl32.Vec2{p.current, control1, points[0], points[1]})
+		p.lastCubic = points[0]
+
+	case 'Q', 'q', 'T', 't':
+		var control mgl32.Vec2
+		if c == 'Q' || c == 'q' {
+			point, err := p.point(origin)
+			if err != nil {
+				return err
+			}
+			control = point
+		} else {
+			control = p.current
+			if strings.IndexByte("QqTt", previous) >= 0 {
+				control = p.current.Mul(2).Sub(p.lastQuad)
+			}
+		}
+
+		point, err := p.point(origin)
+		if err != nil {
+			return err
+		}
+		p.add(quadSegment(p.current, control, point))
+		p.lastQuad = control
+
+	case 'A', 'a':
+		values := [3]float32{}
+		for i := range values {
+			v, err := p.number()
+			if err != nil {
+				return err
+			}
+			values[i] = v
+		}
+		largeArc, err := p.flag()
+		if err != nil {
+			return err
+		}
+		sweep, err := p.flag()
+		if err != nil {
+			return err
+		}
+		point, err := p.point(origin)
+		if err != nil {
+			return err
+		}
+
+		for _, segment := range arcSegments(
+			p.current,
+			float64(values[0]),
+			float64(values[1]),
+			float64(values[2]),
+			largeArc,
+			sweep,
+			point,
+		) {
+			p.add(segment)
+		}
+		p.current = point
+
+	case 'Z', 'z':
+		if p.current != p.start {
+			p.add(lineSegment(p.current, p.start))
+		}
+		p.paths[len(p.paths)-1].Closed = true
+		p.current = p.start
+	}
+
+	return nil
+}
+
+// add : Appends the segment to the current subpath and moves to its end
+func (p *svgParser) add(segment [4]mgl32.Vec2) {
+	path := p.paths[len(p.paths)-1]
+	if path.Closed {
+		// Drawing after a closepath starts a new subpath at the same point
+		path = &Path{}
+		p.paths = append(p.paths, path)
+	}
+
+	path.Segments = append(path.Segments, segment)
+	p.current = segment[3]
+}
+
+func (p *svgParser) points(origin mgl32.Vec2, count int) ([]mgl32.Vec2, error) {
+	result := []mgl32.Vec2{}
+	for i := 0; i < count; i++ {
+		point, err := p.point(origin)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, point)
+	}
+	return result, nil
+}
+
+func (p *svgParser) point(origin mgl32.Vec2) (mgl32.Vec2, error) {
+	x, err := p.number()
+	if err != nil {
+		return mgl32.Vec2{}, err
+	}
+	y, err := p.number()
+	if err != nil {
+		return mgl32.Vec2{}, err
+	}
+	return mgl32.Vec2{x, y}.Add(origin), nil
+}
+
+// number : Reads a number, which may follow the previous number without a
+// separator when unambiguous, for example "1.5.5" or "10-20"
+func (p *svgParser) number() (float32, error) {
+	p.skipSeparators()
+	start := p.pos
+
+	if p.pos < len(p.data) && (p.data[p.pos] == '+' || p.data[p.pos] == '-') {
+		p.pos++
+	}
+
+	digits := 0
+	for p.pos < len(p.data) && isDigit(p.data[p.pos]) {
+		p.pos++
+		digits++
+	}
+	if p.pos < len(p.data) && p.data[p.pos] == '.' {
+		p.pos++
+		for p.pos < len(p.data) && isDigit(p.data[p.pos]) {
+			p.pos++
+			digits++
+		}
+	}
+	if digits == 0 {
+		return 0, fmt.Errorf("expected number at %v", start)
+	}
+
+	if p.pos < len(p.data) && (p.data[p.pos] == 'e' || p.data[p.pos] == 'E') {
+		exponent := p.pos
+		p.pos++
+		if p.pos < len(p.data) && (p.data[p.pos] == '+' || p.data[p.pos] == '-') {
+			p.pos++
+		}
+		if p.pos >= len(p.data) || !isDigit(p.data[p.pos]) {
+			p.pos = exponent
+		}
+		for p.pos < len(p.data) && isDigit(p.data[p.pos]) {
+			p.pos++
+		}
+	}
+
+	v, err := strconv.ParseFloat(p.data[start:p.pos], 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number at %v: %v", start, err)
+	}
+	return float32(v), nil
+}
+
+// flag : Reads an arc flag, which is a single 0 or 1 and need not be
+// followed by a separator
+func (p *svgParser) flag() (bool, error) {
+	p.skipSeparators()
+	if p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case '0':
+			p.pos++
+			return false, nil
+		case '1':
+			p.pos++
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("expected flag at %v", p.pos)
+}
+
+func (p *svgParser) skipSeparators() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\n', '\r', ',':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func isCommand(c byte) bool {
+	return strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", c) >= 0
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,123 @@
+package obj
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+var pathDataTests = []struct {
+	name     string
+	data     string
+	segments int
+	end      mgl32.Vec2
+	length   float32
+}{
+	{"lines", "M 0 0 L 100 0 V 50 H 0", 3, mgl32.Vec2{0, 50}, 250},
+	{"relative lines", "m10,10 l90,0 v50 h-100", 3, mgl32.Vec2{0, 60}, 240},
+	{"implicit lineto", "M0 0 100 0 100 100", 2, mgl32.Vec2{100, 100}, 200},
+	{"closed", "M0 0 L 30 0 L 30 40 Z", 3, mgl32.Vec2{0, 0}, 120},
+	{"compact numbers", "M0-10L.5.5-1e1-10", 2, mgl32.Vec2{-10, -10}, 0},
+	{"cubic", "M 0 0 C 0 0, 100 0, 100 0", 1, mgl32.Vec2{100, 0}, 100},
+	{"smooth cubic", "M 0 0 C 0 10 40 10 40 0 S 80 -10 80 0", 2, mgl32.Vec2{80, 0}, 0},
+	{"quadratic", "M 0 0 Q 50 0 100 0 T 200 0", 2, mgl32.Vec2{200, 0}, 200},
+	{"semicircle", "M 0 0 A 50 50 0 0 1 100 0", 2, mgl32.Vec2{100, 0}, 50 * math.Pi},
+	{"relative arc", "M 100 0 a 50 50 0 1 0 -100 0", 2, mgl32.Vec2{0, 0}, 50 * math.Pi},
+	{"compact arc flags", "M 0 0 a50 50 0 1110 0", 4, mgl32.Vec2{10, 0}, 0},
+	{"repeated moveto", "M0 0 M10 10 L20 20", 1, mgl32.Vec2{20, 20}, 0},
+	{"trailing moveto", "m10 0 h10 m5 5", 1, mgl32.Vec2{20, 0}, 10},
+}
+
+// TestParsePathData : Test each command produces the expected segments
+// and path length
+func TestParsePathData(t *testing.T) {
+	for _, test := range pathDataTests {
+		t.Run(test.name, func(t *testing.T) {
+			path, err := ParsePathData(test.data)
+			if err != nil {
+				t.Fatalf("ParsePathData failed: %v", err)
+			}
+
+			if len(path.Segments) != test.segments {
+				t.Errorf("Segments was (%v) should be (%v)", len(path.Segments), test.segments)
+			}
+
+			end := path.Segments[len(path.Segments)-1][3]
+			if !end.ApproxEqualThreshold(test.end, epsilon) {
+				t.Errorf("End was (%v) should be (%v)", end, test.end)
+			}
+
+			if test.length > 0 && math.Abs(float64(path.Length()-test.length)) > 0.001*float64(test.length) {
+				t.Errorf("Length was (%v) should be (%v)", path.Length(), test.length)
+			}
+		})
+	}
+}
+
+// TestSemicircleMidpoint : Test that arcs follow the sweep direction
+func TestSemicircleMidpoint(t *testing.T) {
+	var sweepTests = []struct {
+		data string
+		mid  mgl32.Vec2
+	}{
+		{"M 0 0 A 50 50 0 0 1 100 0", mgl32.Vec2{50, -50}},
+		{"M 0 0 A 50 50 0 0 0 100 0", mgl32.Vec2{50, 50}},
+	}
+
+	for _, test := range sweepTests {
+		path, _ := ParsePathData(test.data)
+		mid := path.PositionAt(0.5)
+		if !mid.ApproxEqualThreshold(test.mid, 0.1) {
+			t.Errorf("Midpoint of (%v) was (%v) should be (%v)", test.data, mid, test.mid)
+		}
+	}
+}
+
+// TestPathDataErrors : Test that invalid path data is rejected
+func TestPathDataErrors(t *testing.T) {
+	for _, data := range []string{
+		"L 10 10",
+		"M 10",
+		"M 0 0 L 10 x",
+		"M 0 0 A 10 10 0 2 0 10 10",
+		"M 0 0 L 1 1 Z 5",
+		"M 0 0 L 1 1 M 5 5 L 6 6",
+		"M 0 0 M 5 5",
+	} {
+		if _, err := ParsePathData(data); err == nil {
+			t.Errorf("ParsePathData(%q) should fail", data)
+		}
+	}
+
+	paths, err := ParseSubpaths("M 0 0 L 1 1 M 5 5 L 6 6")
+	if err != nil || len(paths) != 2 {
+		t.Errorf("ParseSubpaths was (%v, %v) should be 2 paths", len(paths), err)
+	}
+}
+
+// TestSVGPathDataRoundTrip : Test that exported path data parses back to
+// the same segments
+func TestSVGPathDataRoundTrip(t *testing.T) {
+	original := CreatePath([]int{0, 0, 100, 50, 200, 0})
+	original.Closed = true
+
+	data := original.SVGPathData()
+	parsed, err := ParsePathData(data)
+	if err != nil {
+		t.Fatalf("ParsePathData(%q) failed: %v", data, err)
+	}
+
+	// Closing adds a line back to the start
+	if len(parsed.Segments) != len(original.Segments)+1 || !parsed.Closed {
+		t.Fatalf("Parsed (%q) had (%v) segments should be (%v)",
+			data, len(parsed.Segments), len(original.Segments)+1)
+	}
+	for i, s := range original.Segments {
+		for j := range s {
+			if !parsed.Segments[i][j].ApproxEqualThreshold(s[j], epsilon) {
+				t.Errorf("Segment %v point %v was (%v) should be (%v)", i, j, parsed.Segments[i][j], s[j])
+			}
+		}
+	}
+}
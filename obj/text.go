@@ -0,0 +1,66 @@
+package obj
+
+import (
+	"fmt"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+	"github.com/leedenison/gologo/render"
+	"github.com/leedenison/gologo/time"
+)
+
+// Text : Returns an object drawing a line of text in the font, size pixels
+// per em, centered horizontally on the origin with its baseline through
+// the origin.  font may be nil to use render.DefaultFont.
+func Text(origin mgl32.Vec2, text string, font *render.Font, size float64, color mgl32.Vec4) *gologo.Object {
+	return TextBox(origin, text, font, size, color, render.TextLayout{})
+}
+
+// TextBox : Returns an object drawing text in the font, size pixels per
+// em, broken into lines and placed around the origin according to the
+// layout.  font may be nil to use render.DefaultFont.
+func TextBox(origin mgl32.Vec2, text string, font *render.Font, size float64, color mgl32.Vec4, layout render.TextLayout) *gologo.Object {
+	face, err := textFace(font, size)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to create Text renderer: %v\n", err))
+	}
+
+	textRenderer := render.NewTextRenderer(face, text, color)
+	textRenderer.Layout = layout
+
+	return &gologo.Object{
+		Position: mgl32.Vec3{origin[0], origin[1], 0.0},
+		Scale:    1.0,
+		Creation: time.GetTickTime(),
+		ZOrder:   0,
+		Renderer: textRenderer,
+	}
+}
+
+// MeasureText : Returns the width and height of the text drawn by TextBox
+// with the same arguments, without drawing it
+func MeasureText(text string, font *render.Font, size float64, layout render.TextLayout) mgl32.Vec2 {
+	face, err := textFace(font, size)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to measure text: %v\n", err))
+	}
+
+	return render.MeasureText(face, text, layout)
+}
+
+func textFace(font *render.Font, size float64) (*render.FontFace, error) {
+	if font == nil {
+		font = render.DefaultFont()
+	}
+	return font.Face(size)
+}
+
+// SetText : Replaces the text drawn by an object created by Text or
+// TextBox
+func SetText(object *gologo.Object, text string) {
+	textRenderer, ok := object.Renderer.(*render.TextRenderer)
+	if !ok {
+		panic(fmt.Sprintf("Failed to set text: unsupported renderer %T\n", object.Renderer))
+	}
+	textRenderer.SetText(text)
+}
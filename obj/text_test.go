@@ -0,0 +1,60 @@
+package obj
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+	"github.com/leedenison/gologo/gologotest"
+	"github.com/leedenison/gologo/render"
+)
+
+// TestTextGolden : Test text drawn in the default font on a common
+// baseline, before and after it is changed
+func TestTextGolden(t *testing.T) {
+	g := gologotest.Init(160, 120)
+	defer g.Close()
+
+	baseline := Polyline([]mgl32.Vec2{{0, 70}, {160, 70}},
+		StrokeStyle{Width: 2}, mgl32.Vec4{0.0, 0.0, 1.0, 1.0})
+	title := Text(mgl32.Vec2{80, 70}, "Gologo!", nil, 32, mgl32.Vec4{1.0, 1.0, 0.0, 1.0})
+	score := Text(mgl32.Vec2{80, 30}, "Score: 0", nil, 16, mgl32.Vec4{1.0, 1.0, 1.0, 1.0})
+	SetText(score, "Score: 1250")
+
+	gologotest.AssertScene(t, g, "text", []*gologo.Object{baseline, title, score}, 0)
+}
+
+// TestUnicodeTextGolden : Test text outside ASCII, with a replacement
+// glyph for a character missing from the font
+func TestUnicodeTextGolden(t *testing.T) {
+	g := gologotest.Init(160, 120)
+	defer g.Close()
+
+	accents := Text(mgl32.Vec2{80, 80}, "Crème brûlée", nil, 20, mgl32.Vec4{1.0, 1.0, 1.0, 1.0})
+	greek := Text(mgl32.Vec2{80, 50}, "Ωμέγα Жук", nil, 20, mgl32.Vec4{0.0, 1.0, 1.0, 1.0})
+	missing := Text(mgl32.Vec2{80, 20}, "Go 中", nil, 20, mgl32.Vec4{1.0, 0.5, 0.0, 1.0})
+
+	gologotest.AssertScene(t, g, "unicode", []*gologo.Object{accents, greek, missing}, 0)
+}
+
+// TestTextBoxGolden : Test wrapped, aligned and marked up text boxes
+func TestTextBoxGolden(t *testing.T) {
+	g := gologotest.Init(160, 120)
+	defer g.Close()
+
+	white := mgl32.Vec4{1.0, 1.0, 1.0, 1.0}
+	wrapped := TextBox(mgl32.Vec2{5, 115},
+		"The quick brown fox jumps over the lazy dog.",
+		nil, 12, white,
+		render.TextLayout{MaxWidth: 70, Align: render.AlignLeft, Anchor: render.AnchorTop})
+	right := TextBox(mgl32.Vec2{155, 115},
+		"Right\naligned\ntext",
+		nil, 12, mgl32.Vec4{0.0, 1.0, 1.0, 1.0},
+		render.TextLayout{Align: render.AlignRight, Anchor: render.AnchorTop, LineSpacing: 1.5})
+	markup := TextBox(mgl32.Vec2{80, 25},
+		"[size=20][color=red]Game Over[/color][/size]\nScore: [b]1250[/b] [color=#ff08]x2[/color]",
+		nil, 12, white,
+		render.TextLayout{Anchor: render.AnchorMiddle, Markup: true})
+
+	gologotest.AssertScene(t, g, "textbox", []*gologo.Object{wrapped, right, markup}, 0)
+}
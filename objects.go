@@ -9,18 +9,38 @@ import (
 )
 
 // Object : Struct to hold fundamental object for gologo
-// Position is the position of the object origin in world space
+// ID is a unique identifier assigned when first added to a Scene if zero
+// Position is the position of the object origin relative to its parent,
+// or in world space if it has no parent
 // Orientation is the rotation of the object in radians
 // ZOrder is the gologo managed height order of the objects - 0 is valid
 // Creation is a automatically managed time the object was created
 // Renderer is the gl renderer for this object - can be nil
+// Parent is the object this object is attached to - can be nil
+// Children are the objects attached to this object
 type Object struct {
+	ID          int
 	Position    mgl32.Vec3
 	Orientation float64
 	Scale       float64
 	ZOrder      int
 	Creation    int
 	Renderer    render.Renderer
+	Parent      *Object
+	Children    []*Object
+
+	previous objectState
+	scenes   []*Scene
+}
+
+// objectState : The state of an object before the last fixed update,
+// used to interpolate between updates when drawing.  Saved is false if the
+// state has never been saved because the object is not in a scene.
+type objectState struct {
+	Position    mgl32.Vec3
+	Orientation float64
+	Scale       float64
+	Saved       bool
 }
 
 func CreateObject(position mgl32.Vec3) *Object {
@@ -36,12 +56,67 @@ func (o *Object) Draw() {
 	o.Renderer.Render(o.GetModel())
 }
 
-// GetModel : Returns the model for this object
+// DrawInterpolated : Draws the object interpolated by alpha between its
+// state before the last fixed update and its current state
+func (o *Object) DrawInterpolated(alpha float64) {
+	model := o.GetInterpolatedModel(alpha)
+	o.Renderer.Animate(model)
+	o.Renderer.Render(model)
+}
+
+// GetModel : Returns the model for this object, which is the model of
+// its parent, if it has one, combined with its local model
 func (o *Object) GetModel() mgl32.Mat4 {
-	translate := mgl32.Translate3D(o.Position.X(), o.Position.Y(), o.Position.Z())
-	scale := mgl32.Scale3D(float32(o.Scale), float32(o.Scale), 1.0)
-	rotate := mgl32.HomogRotate3DZ(float32(o.Orientation))
-	return translate.Mul4(rotate.Mul4(scale))
+	if o.Parent != nil {
+		return o.Parent.GetModel().Mul4(o.GetLocalModel())
+	}
+	return o.GetLocalModel()
+}
+
+// GetLocalModel : Returns the model for this object relative to its parent
+func (o *Object) GetLocalModel() mgl32.Mat4 {
+	return modelMatrix(o.Position, o.Orientation, o.Scale)
+}
+
+// GetInterpolatedModel : Returns the model for this object interpolated
+// by alpha between its state before the last fixed update and its current
+// state.  Orientation is interpolated in the direction of the smallest angle.
+// Objects whose state has never been saved, such as parents which are not
+// in a scene, are not interpolated.
+func (o *Object) GetInterpolatedModel(alpha float64) mgl32.Mat4 {
+	p := o.previous
+	if !p.Saved {
+		p = o.currentState()
+	}
+	position := p.Position.Add(o.Position.Sub(p.Position).Mul(float32(alpha)))
+	orientation := p.Orientation + shortestAngle(p.Orientation, o.Orientation)*alpha
+	scale := p.Scale + (o.Scale-p.Scale)*alpha
+	local := modelMatrix(position, orientation, scale)
+
+	if o.Parent != nil {
+		return o.Parent.GetInterpolatedModel(alpha).Mul4(local)
+	}
+	return local
+}
+
+func (o *Object) saveState() {
+	o.previous = o.currentState()
+}
+
+func (o *Object) currentState() objectState {
+	return objectState{
+		Position:    o.Position,
+		Orientation: o.Orientation,
+		Scale:       o.Scale,
+		Saved:       true,
+	}
+}
+
+func modelMatrix(position mgl32.Vec3, orientation float64, scale float64) mgl32.Mat4 {
+	translate := mgl32.Translate3D(position.X(), position.Y(), position.Z())
+	scaleMat := mgl32.Scale3D(float32(scale), float32(scale), 1.0)
+	rotate := mgl32.HomogRotate3DZ(float32(orientation))
+	return translate.Mul4(rotate.Mul4(scaleMat))
 }
 
 // WorldSpace : Returns the world space point corresponding to the supplied object space co-ordinate
@@ -49,6 +124,68 @@ func (o *Object) WorldSpace(c mgl32.Vec3) mgl32.Vec3 {
 	return o.GetModel().Mul4x1(c.Vec4(1.0)).Vec3()
 }
 
+// LocalSpace : Returns the object space co-ordinate corresponding to the supplied world space point
+func (o *Object) LocalSpace(c mgl32.Vec3) mgl32.Vec3 {
+	return o.GetModel().Inv().Mul4x1(c.Vec4(1.0)).Vec3()
+}
+
+// ParentSpace : Returns the co-ordinate relative to the parent corresponding
+// to the supplied world space point.  This is world space if there is no parent.
+func (o *Object) ParentSpace(c mgl32.Vec3) mgl32.Vec3 {
+	if o.Parent == nil {
+		return c
+	}
+	return o.Parent.LocalSpace(c)
+}
+
+// WorldPosition : Returns the position of the object origin in world space
+func (o *Object) WorldPosition() mgl32.Vec3 {
+	return o.WorldSpace(mgl32.Vec3{})
+}
+
+// SetWorldPosition : Sets the position of the object so that its origin
+// is at the supplied world space point
+func (o *Object) SetWorldPosition(c mgl32.Vec3) {
+	o.Position = o.ParentSpace(c)
+}
+
+// WorldOrientation : Returns the rotation of the object in radians in world
+// space, including the rotation of its ancestors
+func (o *Object) WorldOrientation() float64 {
+	if o.Parent == nil {
+		return o.Orientation
+	}
+	return o.Parent.WorldOrientation() + o.Orientation
+}
+
+// WorldScale : Returns the scale of the object in world space, including
+// the scale of its ancestors
+func (o *Object) WorldScale() float64 {
+	if o.Parent == nil {
+		return o.Scale
+	}
+	return o.Parent.WorldScale() * o.Scale
+}
+
+// ContainsPoint : Returns true if the world space point falls on the mesh
+// drawn by the object's renderer, taking into account the position,
+// rotation and scale of the object and its ancestors.  Returns false if the
+// renderer does not implement render.Hittable.
+func (o *Object) ContainsPoint(point mgl32.Vec2) bool {
+	hittable, ok := o.Renderer.(render.Hittable)
+	if !ok {
+		return false
+	}
+
+	model := o.GetModel()
+	if model.Det() == 0 {
+		return false
+	}
+
+	local := model.Inv().Mul4x1(point.Vec4(0, 1))
+	return hittable.Contains(local.Vec2())
+}
+
 // GetAge : Returns age of object since creation
 func (o *Object) GetAge() int {
 	return time.GetTickTime() - o.Creation
@@ -115,9 +252,17 @@ func (o *Object) GetZOrder() int {
 }
 
 // SetZOrder : Sets the height of the object in 3D space
-// as an integer compared with other objects
+// as an integer compared with other objects.  Scenes containing
+// the object are kept in z-order.
 func (o *Object) SetZOrder(z int) {
+	if o.ZOrder == z {
+		return
+	}
+
 	o.ZOrder = z
+	for _, scene := range o.scenes {
+		scene.reorder(o)
+	}
 }
 
 // GetRenderer : Returns the renderer for this object
@@ -134,14 +279,26 @@ func (o *Object) SetRenderer(renderer render.Renderer, clone bool) {
 	}
 }
 
-// Clone : creates a distinct copy of the receiving object
+// Clone : creates a distinct copy of the receiving object.  Children are
+// cloned recursively and attached to the copy.  The copy has no ID or
+// parent and is not in any scene.
 func (o *Object) Clone() *Object {
 	objectCopy := *o
+	objectCopy.ID = 0
+	objectCopy.scenes = nil
+	objectCopy.Parent = nil
+	objectCopy.Children = nil
 
 	if o.Renderer != nil {
 		objectCopy.Renderer = o.Renderer.Clone()
 	}
 
+	for _, child := range o.Children {
+		childCopy := child.Clone()
+		childCopy.Parent = &objectCopy
+		objectCopy.Children = append(objectCopy.Children, childCopy)
+	}
+
 	return &objectCopy
 }
 
@@ -0,0 +1,253 @@
+package render
+
+import (
+	"fmt"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo/time"
+)
+
+// LoopMode : What an animation does after its last frame
+type LoopMode int
+
+const (
+	// LoopRepeat : Starts again from the first frame
+	LoopRepeat LoopMode = iota
+	// LoopOnce : Stops on the last frame
+	LoopOnce
+	// LoopPingPong : Plays backwards to the first frame, then forwards
+	// again
+	LoopPingPong
+)
+
+// ParseLoopMode : Returns the loop mode named "repeat", "once" or
+// "pingpong".  An empty name is LoopRepeat.
+func ParseLoopMode(name string) (LoopMode, error) {
+	switch name {
+	case "", "repeat":
+		return LoopRepeat, nil
+	case "once":
+		return LoopOnce, nil
+	case "pingpong":
+		return LoopPingPong, nil
+	}
+	return LoopRepeat, fmt.Errorf("unknown loop mode: %q", name)
+}
+
+// Animation : A named sequence of frames of a sprite sheet.  Frames are
+// indexes into the sheet's frames, each shown for the matching Durations
+// in milliseconds.
+type Animation struct {
+	Name      string
+	Frames    []int
+	Durations []int
+	Loop      LoopMode
+}
+
+/////////////////////////////////////////////////////////////
+// AnimationRenderer
+//
+
+// AnimationRenderer : Draws the current frame of an animation from a
+// sprite sheet.  Animate advances the animation by the game clock time
+// since it was last called, so animations stop while the clock is paused
+// and follow its scale.  Frame is the position in the animation's frames
+// and Elapsed the milliseconds the frame has been shown for.  OnFinish,
+// if not nil, is called with the name of the animation when an animation
+// played once ends, and each time a looping animation returns to its
+// first frame.
+type AnimationRenderer struct {
+	Sprite    *SpriteRenderer
+	Sheet     *SpriteSheet
+	Animation *Animation
+	Frame     int
+	Elapsed   int
+	Playing   bool
+	OnFinish  func(name string)
+	direction int
+	lastTick  int
+}
+
+// NewAnimationRenderer : Creates a renderer playing the named animation
+// of the sprite sheet
+func NewAnimationRenderer(sheet *SpriteSheet, animation string) (*AnimationRenderer, error) {
+	if len(sheet.Frames) == 0 {
+		return nil, fmt.Errorf("sprite sheet has no frames")
+	}
+
+	sprite, err := NewSpriteRenderer(sheet.Texture, sheet.Frames[0].Region)
+	if err != nil {
+		return nil, err
+	}
+
+	renderer := &AnimationRenderer{
+		Sprite: sprite,
+		Sheet:  sheet,
+	}
+	if err := renderer.Play(animation); err != nil {
+		return nil, err
+	}
+	return renderer, nil
+}
+
+// Play : Starts the named animation from its first frame.  Animations
+// whose frames are not in the sheet, or which do not have a duration for
+// each frame, are rejected.
+func (r *AnimationRenderer) Play(name string) error {
+	animation, ok := r.Sheet.Animations[name]
+	if !ok {
+		return fmt.Errorf("unknown animation: %q", name)
+	}
+	if len(animation.Frames) == 0 {
+		return fmt.Errorf("animation %q has no frames", name)
+	}
+	if len(animation.Durations) != len(animation.Frames) {
+		return fmt.Errorf("animation %q has %v durations for %v frames",
+			name, len(animation.Durations), len(animation.Frames))
+	}
+	for _, frame := range animation.Frames {
+		if frame < 0 || frame >= len(r.Sheet.Frames) {
+			return fmt.Errorf("animation %q: frame %v out of range", name, frame)
+		}
+	}
+
+	r.Animation = animation
+	r.Frame = 0
+	r.Elapsed = 0
+	r.Playing = true
+	r.direction = 1
+	r.lastTick = time.GetTickTime()
+	r.showFrame()
+	return nil
+}
+
+// Stop : Stops the animation on its current frame.  Setting Playing
+// resumes it.
+func (r *AnimationRenderer) Stop() {
+	r.Playing = false
+}
+
+// CurrentFrame : Returns the sprite sheet frame being drawn
+func (r *AnimationRenderer) CurrentFrame() Frame {
+	return r.Sheet.Frames[r.Animation.Frames[r.Frame]]
+}
+
+// Advance : Advances the animation by ms milliseconds, moving through as
+// many frames as have ended
+func (r *AnimationRenderer) Advance(ms int) {
+	if !r.Playing {
+		return
+	}
+
+	animation := r.Animation
+	r.Elapsed += ms
+	for r.Playing && r.Animation == animation {
+		duration := animation.Durations[r.Frame]
+		if duration <= 0 {
+			duration = 1
+		}
+		if r.Elapsed < duration {
+			break
+		}
+		r.Elapsed -= duration
+		r.nextFrame()
+	}
+	r.showFrame()
+}
+
+// nextFrame : Moves to the next frame according to the loop mode, calling
+// OnFinish at the end of the animation
+func (r *AnimationRenderer) nextFrame() {
+	count := len(r.Animation.Frames)
+	next := r.Frame + r.direction
+	finished := false
+
+	switch r.Animation.Loop {
+	case LoopRepeat:
+		if next >= count {
+			next = 0
+			finished = true
+		}
+	case LoopOnce:
+		if next >= count {
+			next = count - 1
+			r.Playing = false
+			r.Elapsed = 0
+			finished = true
+		}
+	case LoopPingPong:
+		if next >= count || next < 0 {
+			r.direction = -r.direction
+			next = r.Frame + r.direction
+			if next >= count || next < 0 {
+				next = 0
+			}
+		}
+		finished = next == 0
+	}
+
+	r.Frame = next
+	if finished && r.OnFinish != nil {
+		r.OnFinish(r.Animation.Name)
+	}
+}
+
+// showFrame : Sets the sprite to draw the current frame
+func (r *AnimationRenderer) showFrame() {
+	r.Sprite.Region = r.CurrentFrame().Region
+}
+
+// frameModel : Returns the model moved by the offset of the current frame,
+// mirrored along flipped axes
+func (r *AnimationRenderer) frameModel(model mgl32.Mat4) mgl32.Mat4 {
+	offset := r.CurrentFrame().Offset
+	if r.Sprite.FlipX {
+		offset[0] = -offset[0]
+	}
+	if r.Sprite.FlipY {
+		offset[1] = -offset[1]
+	}
+	return model.Mul4(mgl32.Translate3D(offset[0], offset[1], 0))
+}
+
+func (r *AnimationRenderer) Render(model mgl32.Mat4) {
+	r.RenderAt(model, map[int]interface{}{})
+}
+
+func (r *AnimationRenderer) RenderAt(model mgl32.Mat4, custom map[int]interface{}) {
+	r.Sprite.RenderAt(r.frameModel(model), custom)
+}
+
+func (r *AnimationRenderer) DebugRender(model mgl32.Mat4) {
+	r.DebugRenderAt(model, map[int]interface{}{})
+}
+
+func (r *AnimationRenderer) DebugRenderAt(model mgl32.Mat4, custom map[int]interface{}) {
+	r.Sprite.DebugRenderAt(r.frameModel(model), custom)
+}
+
+// Animate : Advances the animation by the game clock time since the last
+// call
+func (r *AnimationRenderer) Animate(model mgl32.Mat4) {
+	tick := time.GetTickTime()
+	if r.Playing {
+		r.Advance(tick - r.lastTick)
+	}
+	r.lastTick = tick
+}
+
+// Clone : Clones an AnimationRenderer, including its position in the
+// current animation.  The sprite sheet is shared.
+func (r *AnimationRenderer) Clone() Renderer {
+	return &AnimationRenderer{
+		Sprite:    r.Sprite.Clone().(*SpriteRenderer),
+		Sheet:     r.Sheet,
+		Animation: r.Animation,
+		Frame:     r.Frame,
+		Elapsed:   r.Elapsed,
+		Playing:   r.Playing,
+		OnFinish:  r.OnFinish,
+		direction: r.direction,
+		lastTick:  r.lastTick,
+	}
+}
@@ -0,0 +1,229 @@
+package render
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo/time"
+)
+
+// animationSheet : Returns a sheet of four 8x8 frames in a row, with
+// frame i lasting 10*(i+1) milliseconds
+func animationSheet(t *testing.T) *SpriteSheet {
+	if _, err := InitSoftware(8, 8); err != nil {
+		t.Fatalf("InitSoftware failed: %v", err)
+	}
+
+	sheet, err := NewGridSheet(&GLTexture{Size: [2]uint32{32, 8}}, 8, 8, 0)
+	if err != nil {
+		t.Fatalf("NewGridSheet failed: %v", err)
+	}
+	for i := range sheet.Frames {
+		sheet.Frames[i].Duration = 10 * (i + 1)
+	}
+	return sheet
+}
+
+var advanceTests = []struct {
+	name     string
+	loop     LoopMode
+	steps    []int
+	frames   []int
+	finished int
+}{
+	{"repeat", LoopRepeat, []int{5, 5, 20, 30, 40, 10}, []int{0, 1, 2, 3, 0, 1}, 1},
+	{"once", LoopOnce, []int{10, 20, 30, 40, 50}, []int{1, 2, 3, 3, 3}, 1},
+	{"pingpong", LoopPingPong, []int{10, 20, 30, 40, 30, 20}, []int{1, 2, 3, 2, 1, 0}, 1},
+	{"several frames at once", LoopRepeat, []int{60, 45}, []int{3, 0}, 1},
+}
+
+// TestAnimationAdvance : Test that frames are shown for their durations
+// and the animation loops according to its mode
+func TestAnimationAdvance(t *testing.T) {
+	for _, tc := range advanceTests {
+		t.Run(tc.name, func(t *testing.T) {
+			sheet := animationSheet(t)
+			if _, err := sheet.AddAnimation("walk", FrameRange(0, 3), tc.loop); err != nil {
+				t.Fatalf("AddAnimation failed: %v", err)
+			}
+
+			renderer, err := NewAnimationRenderer(sheet, "walk")
+			if err != nil {
+				t.Fatalf("NewAnimationRenderer failed: %v", err)
+			}
+			finished := 0
+			renderer.OnFinish = func(name string) { finished++ }
+
+			frames := []int{}
+			for _, step := range tc.steps {
+				renderer.Advance(step)
+				frames = append(frames, renderer.Frame)
+			}
+
+			if !reflect.DeepEqual(frames, tc.frames) {
+				t.Errorf("Frames were (%v) should be (%v)", frames, tc.frames)
+			}
+			if finished != tc.finished {
+				t.Errorf("Finished was (%v) should be (%v)", finished, tc.finished)
+			}
+			if region := renderer.Sprite.Region; region != renderer.CurrentFrame().Region {
+				t.Errorf("Region was (%v) should be (%v)", region, renderer.CurrentFrame().Region)
+			}
+		})
+	}
+}
+
+// TestAnimationGameClock : Test that Animate advances the animation by
+// game clock time, which stops while the clock is paused
+func TestAnimationGameClock(t *testing.T) {
+	sheet := animationSheet(t)
+	if _, err := sheet.AddAnimation("idle", []int{2, 0}, LoopRepeat); err != nil {
+		t.Fatalf("AddAnimation failed: %v", err)
+	}
+
+	clock := &time.ManualClock{}
+	time.SetClock(clock)
+	time.InitTick()
+	defer time.SetClock(time.NewSystemClock())
+
+	renderer, err := NewAnimationRenderer(sheet, "idle")
+	if err != nil {
+		t.Fatalf("NewAnimationRenderer failed: %v", err)
+	}
+
+	clock.Step(29)
+	time.Tick()
+	renderer.Animate(mgl32.Ident4())
+	if renderer.Frame != 0 || renderer.Elapsed != 29 {
+		t.Errorf("Frame was (%v, %v) should be (%v, %v)", renderer.Frame, renderer.Elapsed, 0, 29)
+	}
+
+	time.Pause()
+	clock.Step(100)
+	time.Tick()
+	renderer.Animate(mgl32.Ident4())
+	time.Resume()
+
+	clock.Step(1)
+	time.Tick()
+	renderer.Animate(mgl32.Ident4())
+	if renderer.Frame != 1 || renderer.CurrentFrame().Name != "0" {
+		t.Errorf("Frame was (%v, %q) should be (%v, %q)", renderer.Frame, renderer.CurrentFrame().Name, 1, "0")
+	}
+}
+
+// TestParseSpriteSheet : Test a JSON frame list with named animations
+func TestParseSpriteSheet(t *testing.T) {
+	data := []byte(`{
+		"image": "hero.png",
+		"frames": [
+			{"name": "a", "x": 0, "y": 0, "w": 16, "h": 24, "duration": 50},
+			{"name": "b", "x": 16, "y": 0, "w": 16, "h": 24}
+		],
+		"animations": [{"name": "jump", "frames": [1, 0], "loop": "once"}]
+	}`)
+
+	sheet, err := ParseSpriteSheet(&GLTexture{}, data)
+	if err != nil {
+		t.Fatalf("ParseSpriteSheet failed: %v", err)
+	}
+
+	if len(sheet.Frames) != 2 || sheet.Frames[1].Region.Min.X != 16 || sheet.Frames[1].Region.Dy() != 24 {
+		t.Errorf("Frames were (%v)", sheet.Frames)
+	}
+	jump := sheet.Animations["jump"]
+	if jump == nil {
+		t.Fatalf("Animation (jump) should exist")
+	}
+	if !reflect.DeepEqual(jump.Durations, []int{defaultFrameDuration, 50}) || jump.Loop != LoopOnce {
+		t.Errorf("Animation was (%v, %v) should be (%v, %v)",
+			jump.Durations, jump.Loop, []int{defaultFrameDuration, 50}, LoopOnce)
+	}
+
+	if _, err := ParseSpriteSheet(&GLTexture{}, []byte(`{"animations": [{"name": "x", "frames": [3]}]}`)); err == nil {
+		t.Errorf("Animation with missing frame should fail")
+	}
+	for _, frame := range []string{`{"w": 0, "h": 16}`, `{"w": 16, "h": 0}`, `{"w": -4, "h": 16}`} {
+		if _, err := ParseSpriteSheet(&GLTexture{}, []byte(`{"frames": [`+frame+`]}`)); err == nil {
+			t.Errorf("Frame (%v) should fail", frame)
+		}
+	}
+	if _, err := ParseAsepriteSheet(&GLTexture{}, []byte(`{"frames": [{"frame": {"w": 0, "h": 16}}]}`)); err == nil {
+		t.Errorf("Aseprite frame with zero width should fail")
+	}
+}
+
+// TestPlayInvalidAnimation : Test that animations edited to refer to
+// missing frames, or without a duration for each frame, are not played
+func TestPlayInvalidAnimation(t *testing.T) {
+	sheet := animationSheet(t)
+	if _, err := sheet.AddAnimation("walk", []int{0, 1, 2}, LoopRepeat); err != nil {
+		t.Fatalf("AddAnimation failed: %v", err)
+	}
+	renderer, err := NewAnimationRenderer(sheet, "walk")
+	if err != nil {
+		t.Fatalf("NewAnimationRenderer failed: %v", err)
+	}
+
+	sheet.Animations["short"] = &Animation{Name: "short", Frames: []int{0, 1}, Durations: []int{10}}
+	sheet.Animations["missing"] = &Animation{Name: "missing", Frames: []int{0, 4}, Durations: []int{10, 10}}
+	sheet.Animations["negative"] = &Animation{Name: "negative", Frames: []int{-1}, Durations: []int{10}}
+	for _, name := range []string{"short", "missing", "negative"} {
+		if err := renderer.Play(name); err == nil {
+			t.Errorf("Play (%v) should fail", name)
+		}
+	}
+	if renderer.Animation.Name != "walk" {
+		t.Errorf("Animation was (%v) should be (%v)", renderer.Animation.Name, "walk")
+	}
+}
+
+// TestParseAsepriteSheet : Test an Aseprite export in the hash format,
+// which keeps the order of its frames, with tags and trimmed frames
+func TestParseAsepriteSheet(t *testing.T) {
+	data := []byte(`{
+		"frames": {
+			"hero 2.aseprite": {"frame": {"x": 0, "y": 0, "w": 8, "h": 8}, "trimmed": true,
+				"spriteSourceSize": {"x": 2, "y": 0, "w": 8, "h": 8}, "sourceSize": {"w": 12, "h": 12}, "duration": 30},
+			"hero 0.aseprite": {"frame": {"x": 8, "y": 0, "w": 12, "h": 12},
+				"sourceSize": {"w": 12, "h": 12}, "duration": 10},
+			"hero 1.aseprite": {"frame": {"x": 20, "y": 0, "w": 12, "h": 12},
+				"sourceSize": {"w": 12, "h": 12}, "duration": 20}
+		},
+		"meta": {
+			"image": "hero.png",
+			"frameTags": [
+				{"name": "back", "from": 0, "to": 2, "direction": "reverse"},
+				{"name": "bounce", "from": 1, "to": 2, "direction": "pingpong"}
+			]
+		}
+	}`)
+
+	sheet, err := ParseAsepriteSheet(&GLTexture{}, data)
+	if err != nil {
+		t.Fatalf("ParseAsepriteSheet failed: %v", err)
+	}
+
+	names := []string{}
+	for _, frame := range sheet.Frames {
+		names = append(names, frame.Name)
+	}
+	expectedNames := []string{"hero 2.aseprite", "hero 0.aseprite", "hero 1.aseprite"}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("Frames were (%v) should be (%v)", names, expectedNames)
+	}
+
+	if offset := sheet.Frames[0].Offset; offset != (mgl32.Vec2{0, 2}) {
+		t.Errorf("Offset was (%v) should be (%v)", offset, mgl32.Vec2{0, 2})
+	}
+
+	back := sheet.Animations["back"]
+	if back == nil || !reflect.DeepEqual(back.Frames, []int{2, 1, 0}) || back.Loop != LoopRepeat {
+		t.Errorf("Animation (back) was (%v) should play frames (%v)", back, []int{2, 1, 0})
+	}
+	bounce := sheet.Animations["bounce"]
+	if bounce == nil || !reflect.DeepEqual(bounce.Durations, []int{10, 20}) || bounce.Loop != LoopPingPong {
+		t.Errorf("Animation (bounce) was (%v) should ping-pong with durations (%v)", bounce, []int{10, 20})
+	}
+}
@@ -0,0 +1,87 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"path/filepath"
+
+	"github.com/leedenison/gologo/atlas"
+)
+
+// TextureRegion : A region of a texture, in texture pixels, holding an
+// image packed into an atlas
+type TextureRegion struct {
+	Texture *GLTexture
+	Region  image.Rectangle
+}
+
+/////////////////////////////////////////////////////////////
+// Atlas Resources
+//
+
+// LoadAtlas : Packs the image files into atlas textures.  Afterwards
+// CreateTextureRegion returns the atlas region for each of the paths, so
+// that sprites of the images share a texture.
+func LoadAtlas(paths []string, options atlas.Options) error {
+	pages, err := atlas.Load(paths, options)
+	if err != nil {
+		return err
+	}
+
+	for _, page := range pages {
+		texture, err := backend().CreateTexture(page.Image)
+		if err != nil {
+			return err
+		}
+		for name, region := range page.Regions {
+			glState.Regions[filepath.Clean(name)] = TextureRegion{texture, region}
+		}
+	}
+
+	return nil
+}
+
+// LoadAtlasFile : Loads atlas textures written by the gologo atlas
+// command.  Afterwards CreateTextureRegion returns the atlas region for
+// the path of each packed image, relative to the directory of the JSON
+// file.
+func LoadAtlasFile(path string) error {
+	file, err := atlas.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	for _, page := range file.Pages {
+		texture, err := CreateTexture(filepath.Join(dir, filepath.FromSlash(page.Image)))
+		if err != nil {
+			return err
+		}
+		if texture.Size != [2]uint32{uint32(page.W), uint32(page.H)} {
+			return fmt.Errorf("atlas image %q is %v should be %vx%v", page.Image, texture.Size, page.W, page.H)
+		}
+
+		for _, region := range page.Regions {
+			name := filepath.Join(dir, filepath.FromSlash(region.Name))
+			glState.Regions[filepath.Clean(name)] = TextureRegion{texture, region.Rect()}
+		}
+	}
+
+	return nil
+}
+
+// CreateTextureRegion : Returns the texture holding the image file at
+// path and the region of it covered by the image.  This is a region of an
+// atlas if the file was packed by LoadAtlas or LoadAtlasFile, and
+// otherwise the whole of the texture loaded by CreateTexture.
+func CreateTextureRegion(path string) (*GLTexture, image.Rectangle, error) {
+	if region, ok := glState.Regions[filepath.Clean(path)]; ok {
+		return region.Texture, region.Region, nil
+	}
+
+	texture, err := CreateTexture(path)
+	if err != nil {
+		return nil, image.Rectangle{}, err
+	}
+	return texture, image.Rect(0, 0, int(texture.Size[0]), int(texture.Size[1])), nil
+}
@@ -0,0 +1,111 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leedenison/gologo/atlas"
+)
+
+// TestLoadAtlas : Test that images packed by LoadAtlas share a texture
+// and that other images are loaded as whole textures
+func TestLoadAtlas(t *testing.T) {
+	if _, err := InitSoftware(8, 8); err != nil {
+		t.Fatalf("InitSoftware failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	paths := []string{
+		writeTestImage(t, filepath.Join(dir, "a.png"), 8, 4),
+		writeTestImage(t, filepath.Join(dir, "b.png"), 6, 6),
+	}
+	other := writeTestImage(t, filepath.Join(dir, "c.png"), 5, 3)
+
+	if err := LoadAtlas(paths, atlas.Options{Padding: 1}); err != nil {
+		t.Fatalf("LoadAtlas failed: %v", err)
+	}
+
+	textureA, regionA, err := CreateTextureRegion(paths[0])
+	if err != nil {
+		t.Fatalf("CreateTextureRegion failed: %v", err)
+	}
+	textureB, regionB, err := CreateTextureRegion(paths[1])
+	if err != nil {
+		t.Fatalf("CreateTextureRegion failed: %v", err)
+	}
+	if textureA != textureB {
+		t.Errorf("Packed images should share a texture")
+	}
+	if regionA.Size() != image.Pt(8, 4) || regionB.Size() != image.Pt(6, 6) || regionA.Overlaps(regionB) {
+		t.Errorf("Regions were (%v) and (%v) should be separate 8x4 and 6x6 regions", regionA, regionB)
+	}
+
+	texture, region, err := CreateTextureRegion(other)
+	if err != nil {
+		t.Fatalf("CreateTextureRegion failed: %v", err)
+	}
+	if texture == textureA || region != image.Rect(0, 0, 5, 3) {
+		t.Errorf("Region of unpacked image was (%v) should be (%v) of its own texture", region, image.Rect(0, 0, 5, 3))
+	}
+}
+
+// TestLoadAtlasFile : Test that regions written by atlas.Write are found
+// by the paths of the packed images
+func TestLoadAtlasFile(t *testing.T) {
+	if _, err := InitSoftware(8, 8); err != nil {
+		t.Fatalf("InitSoftware failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	path := writeTestImage(t, filepath.Join(dir, "sprites", "a.png"), 7, 5)
+	pages, err := atlas.Load([]string{path}, atlas.Options{Padding: 2})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	jsonPath := filepath.Join(dir, "atlas.json")
+	if err := atlas.Write(pages, jsonPath); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	if err := LoadAtlasFile(jsonPath); err != nil {
+		t.Fatalf("LoadAtlasFile failed: %v", err)
+	}
+	texture, region, err := CreateTextureRegion(filepath.Join(dir, "sprites", "..", "sprites", "a.png"))
+	if err != nil {
+		t.Fatalf("CreateTextureRegion failed: %v", err)
+	}
+	if region != pages[0].Regions[path] {
+		t.Errorf("Region was (%v) should be (%v)", region, pages[0].Regions[path])
+	}
+	if size := pages[0].Image.Rect.Size(); texture.Size != [2]uint32{uint32(size.X), uint32(size.Y)} {
+		t.Errorf("Texture size was (%v) should be (%v)", texture.Size, size)
+	}
+}
+
+// writeTestImage : Writes an opaque white PNG of the given size and
+// returns its path
+func writeTestImage(t *testing.T, path string, width int, height int) string {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	rgba := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			rgba.SetRGBA(x, y, color.RGBA{255, 255, 255, 255})
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	defer file.Close()
+	if err := png.Encode(file, rgba); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	return path
+}
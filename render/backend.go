@@ -0,0 +1,69 @@
+package render
+
+import (
+	"image"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Backend : The graphics API used to create and draw meshes and textures.
+// OpenGLBackend is used when running in a window and SoftwareBackend
+// when running headless.  A Backend is selected by calling Init.
+type Backend interface {
+	// Init : Configures global state such as blending.  Called once by Init.
+	Init() error
+
+	// Clear : Clears the back buffer
+	Clear()
+
+	// CompileProgram : Compiles and links the named vertex and fragment
+	// shaders, resolving the locations of all known uniforms
+	CompileProgram(vertexShader string, fragmentShader string) (*GLShader, error)
+
+	// CreateMeshBuffer : Uploads the vertices, in the layout given by
+	// shader.VertexStride, and returns a handle to the mesh for use with Draw
+	CreateMeshBuffer(shader *GLShader, vertices []float32) (uint32, error)
+
+	// CreateTexture : Creates a texture from the image
+	CreateTexture(rgba *image.RGBA) (*GLTexture, error)
+
+	// UploadSubImage : Replaces the region of the texture at offset with
+	// the image
+	UploadSubImage(texture *GLTexture, rgba *image.RGBA, offset image.Point)
+
+	// BindUniforms : Selects the shader program and binds the model and
+	// projection matrices along with the supplied uniform values
+	BindUniforms(
+		shader *GLShader,
+		model mgl32.Mat4,
+		projection mgl32.Mat4,
+		uniforms map[int]interface{})
+
+	// Draw : Draws the mesh as triangles using the most recently bound
+	// shader program and uniforms
+	Draw(shader *GLShader, mesh uint32, vertexCount int32)
+}
+
+// Init : Selects the backend used for all subsequent rendering
+func Init(backend Backend) error {
+	if err := backend.Init(); err != nil {
+		return err
+	}
+
+	glState.Backend = backend
+	glState.Shaders = map[string]*GLShader{}
+	glState.Textures = map[string]*GLTexture{}
+	glState.Regions = map[string]TextureRegion{}
+	glState.Fonts = map[string]*Font{}
+
+	CreateMeshRenderer = CreateMeshRendererImpl
+	CreateTexture = CreateTextureImpl
+
+	return nil
+}
+
+// GetBackend : Returns the backend selected by Init, dispatching its calls
+// to the main thread if a dispatcher is set.  See SetDispatcher.
+func GetBackend() Backend {
+	return backend()
+}
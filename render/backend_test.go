@@ -0,0 +1,115 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// recordingBackend : Records the calls made to the backend
+type recordingBackend struct {
+	calls    []string
+	uniforms map[int]interface{}
+}
+
+func (b *recordingBackend) Init() error {
+	b.calls = append(b.calls, "Init")
+	return nil
+}
+
+func (b *recordingBackend) Clear() {
+	b.calls = append(b.calls, "Clear")
+}
+
+func (b *recordingBackend) CompileProgram(vertexShader string, fragmentShader string) (*GLShader, error) {
+	b.calls = append(b.calls, fmt.Sprintf("CompileProgram %v %v", vertexShader, fragmentShader))
+	shader := &GLShader{Program: 1, Uniforms: map[int]int32{}}
+	for uniform := range shaderUniforms {
+		shader.Uniforms[uniform] = int32(uniform)
+	}
+	return shader, nil
+}
+
+func (b *recordingBackend) CreateMeshBuffer(shader *GLShader, vertices []float32) (uint32, error) {
+	b.calls = append(b.calls, fmt.Sprintf("CreateMeshBuffer %v", len(vertices)))
+	return 2, nil
+}
+
+func (b *recordingBackend) CreateTexture(rgba *image.RGBA) (*GLTexture, error) {
+	b.calls = append(b.calls, "CreateTexture")
+	return &GLTexture{ID: 3}, nil
+}
+
+func (b *recordingBackend) UploadSubImage(texture *GLTexture, rgba *image.RGBA, offset image.Point) {
+	b.calls = append(b.calls, fmt.Sprintf("UploadSubImage %v", texture.ID))
+}
+
+func (b *recordingBackend) BindUniforms(
+	shader *GLShader,
+	model mgl32.Mat4,
+	projection mgl32.Mat4,
+	uniforms map[int]interface{},
+) {
+	b.calls = append(b.calls, "BindUniforms")
+	b.uniforms = uniforms
+}
+
+func (b *recordingBackend) Draw(shader *GLShader, mesh uint32, vertexCount int32) {
+	b.calls = append(b.calls, fmt.Sprintf("Draw %v %v", mesh, vertexCount))
+}
+
+// TestBackendMeshRenderer : Test that a MeshRenderer is created and drawn
+// through the selected backend, with custom uniforms taking precedence
+func TestBackendMeshRenderer(t *testing.T) {
+	backend := &recordingBackend{}
+	if err := Init(backend); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	renderer, err := CreateMeshRenderer(
+		"ORTHO_VERTEX_SHADER",
+		"COLOR_FRAGMENT_SHADER",
+		[]int{UniformColor, UniformAlpha},
+		map[int]interface{}{
+			UniformColor: mgl32.Vec4{1.0, 0.0, 0.0, 1.0},
+			UniformAlpha: float32(1.0),
+		},
+		make([]float32, 3*GlMeshStride))
+	if err != nil {
+		t.Fatalf("CreateMeshRenderer failed: %v", err)
+	}
+
+	// A second renderer with the same shaders reuses the compiled program
+	_, err = CreateMeshRenderer(
+		"ORTHO_VERTEX_SHADER",
+		"COLOR_FRAGMENT_SHADER",
+		[]int{UniformColor},
+		map[int]interface{}{},
+		make([]float32, 3*GlMeshStride))
+	if err != nil {
+		t.Fatalf("CreateMeshRenderer failed: %v", err)
+	}
+
+	renderer.RenderAt(mgl32.Ident4(), map[int]interface{}{UniformAlpha: float32(0.5)})
+
+	expected := []string{
+		"Init",
+		"CompileProgram ORTHO_VERTEX_SHADER COLOR_FRAGMENT_SHADER",
+		"CreateMeshBuffer 15",
+		"CreateMeshBuffer 15",
+		"BindUniforms",
+		"Draw 2 3",
+	}
+	if fmt.Sprint(backend.calls) != fmt.Sprint(expected) {
+		t.Errorf("Calls were %v should be %v", backend.calls, expected)
+	}
+
+	if alpha := backend.uniforms[UniformAlpha]; alpha != float32(0.5) {
+		t.Errorf("Alpha was (%v) should be (0.5)", alpha)
+	}
+	if color := backend.uniforms[UniformColor]; color != (mgl32.Vec4{1.0, 0.0, 0.0, 1.0}) {
+		t.Errorf("Color was (%v) should be (%v)", color, mgl32.Vec4{1.0, 0.0, 0.0, 1.0})
+	}
+}
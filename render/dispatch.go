@@ -0,0 +1,83 @@
+package render
+
+import (
+	"image"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+/////////////////////////////////////////////////////////////
+// Dispatch
+//
+
+// SetDispatcher : While dispatch is not nil, every call to the backend is
+// wrapped in a function and passed to dispatch, which must call it on the
+// main thread and wait for it to return.  Used while code which may create
+// meshes or textures, such as a script, runs off the main thread.  Pass
+// nil to call the backend directly again.
+func SetDispatcher(dispatch func(fn func())) {
+	glState.Dispatch = dispatch
+}
+
+// backend : Returns the backend selected by Init, wrapped so that its
+// calls are dispatched to the main thread if a dispatcher is set
+func backend() Backend {
+	if glState.Dispatch == nil || glState.Backend == nil {
+		return glState.Backend
+	}
+	return &dispatchBackend{backend: glState.Backend, dispatch: glState.Dispatch}
+}
+
+// dispatchBackend : Passes every call to the backend to dispatch
+type dispatchBackend struct {
+	backend  Backend
+	dispatch func(fn func())
+}
+
+func (d *dispatchBackend) Init() error {
+	var err error
+	d.dispatch(func() { err = d.backend.Init() })
+	return err
+}
+
+func (d *dispatchBackend) Clear() {
+	d.dispatch(d.backend.Clear)
+}
+
+func (d *dispatchBackend) CompileProgram(vertexShader string, fragmentShader string) (*GLShader, error) {
+	var shader *GLShader
+	var err error
+	d.dispatch(func() { shader, err = d.backend.CompileProgram(vertexShader, fragmentShader) })
+	return shader, err
+}
+
+func (d *dispatchBackend) CreateMeshBuffer(shader *GLShader, vertices []float32) (uint32, error) {
+	var mesh uint32
+	var err error
+	d.dispatch(func() { mesh, err = d.backend.CreateMeshBuffer(shader, vertices) })
+	return mesh, err
+}
+
+func (d *dispatchBackend) CreateTexture(rgba *image.RGBA) (*GLTexture, error) {
+	var texture *GLTexture
+	var err error
+	d.dispatch(func() { texture, err = d.backend.CreateTexture(rgba) })
+	return texture, err
+}
+
+func (d *dispatchBackend) UploadSubImage(texture *GLTexture, rgba *image.RGBA, offset image.Point) {
+	d.dispatch(func() { d.backend.UploadSubImage(texture, rgba, offset) })
+}
+
+func (d *dispatchBackend) BindUniforms(
+	shader *GLShader,
+	model mgl32.Mat4,
+	projection mgl32.Mat4,
+	uniforms map[int]interface{},
+) {
+	d.dispatch(func() { d.backend.BindUniforms(shader, model, projection, uniforms) })
+}
+
+func (d *dispatchBackend) Draw(shader *GLShader, mesh uint32, vertexCount int32) {
+	d.dispatch(func() { d.backend.Draw(shader, mesh, vertexCount) })
+}
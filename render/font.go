@@ -0,0 +1,493 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+	"os"
+
+	"github.com/leedenison/gologo/log"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// Font : A TrueType or OpenType font.  Glyphs are rasterized separately
+// for each size the font is drawn at, see Face.  Characters missing from
+// the font are taken from the first of Fallbacks which has them.  Bold is
+// the font used for bold text, which is emboldened from this font if nil.
+type Font struct {
+	Name      string
+	Fallbacks []*Font
+	Bold      *Font
+	sfnt      *sfnt.Font
+	faces     map[float64]*FontFace
+}
+
+// FontFace : A font rasterized at a single size in pixels.  Glyphs are
+// rasterized the first time each character is drawn and packed into
+// texture atlases, which are added to Atlases as each fills up.  Ascent
+// and Descent are the distances above and below the baseline reserved for
+// glyphs, and LineHeight the distance between baselines.
+type FontFace struct {
+	Font       *Font
+	Size       float64
+	Ascent     float32
+	Descent    float32
+	LineHeight float32
+	Atlases    []*GLTexture
+	Glyphs     map[rune]*Glyph
+
+	backend Backend
+	buffer  sfnt.Buffer
+	page    atlasPage
+	metrics map[rune]glyphMetrics
+}
+
+// glyphMetrics : The font and glyph used to draw a character and its
+// advance, which are all that is needed to lay out text
+type glyphMetrics struct {
+	font    *Font
+	index   sfnt.GlyphIndex
+	advance float32
+}
+
+// Glyph : A single character of a FontFace.  Renderer draws the glyph with
+// the pen position on the baseline at the model origin, and is nil for
+// characters without an outline such as space.  Advance is the distance
+// the pen moves after drawing the glyph.
+type Glyph struct {
+	Renderer *MeshRenderer
+	Advance  float32
+
+	font  *Font
+	index sfnt.GlyphIndex
+}
+
+// atlasPage : The atlas glyphs are currently added to.  Glyphs are placed
+// left to right in rows, starting a new row when the current one is full.
+type atlasPage struct {
+	texture   *GLTexture
+	size      image.Point
+	x         int
+	y         int
+	rowHeight int
+}
+
+const (
+	// glyphPadding : The transparent border around each glyph in the
+	// atlas so that bilinear filtering does not sample its neighbours
+	glyphPadding = 1
+	// replacementChar : Drawn in place of characters which are missing
+	// from the font and its fallbacks
+	replacementChar = '\uFFFD'
+	// minAtlasSize, maxAtlasSize : The range of widths and heights of a
+	// glyph atlas, which is sized to hold around 100 glyphs
+	minAtlasSize = 128
+	maxAtlasSize = 2048
+)
+
+var defaultFont *Font
+
+// LoadFont : Loads the TrueType or OpenType font from the path.  Fonts are
+// cached so that loading the same path again returns the same font.
+func LoadFont(fontPath string) (*Font, error) {
+	result, fontExists := glState.Fonts[fontPath]
+	if !fontExists {
+		data, err := os.ReadFile(fontPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load font %q: %v", fontPath, err)
+		}
+		result, err = ParseFont(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse font %q: %v", fontPath, err)
+		}
+		result.Name = fontPath
+		glState.Fonts[fontPath] = result
+	}
+
+	return result, nil
+}
+
+// ParseFont : Parses a TrueType or OpenType font from its file contents
+func ParseFont(data []byte) (*Font, error) {
+	parsed, err := sfnt.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Font{
+		sfnt:  parsed,
+		faces: map[float64]*FontFace{},
+	}, nil
+}
+
+// DefaultFont : Returns the Go Regular font, with Go Bold as its bold
+// font, which are built in
+func DefaultFont() *Font {
+	if defaultFont == nil {
+		regular, err := ParseFont(goregular.TTF)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to parse default font: %v\n", err))
+		}
+		regular.Name = "Go Regular"
+
+		bold, err := ParseFont(gobold.TTF)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to parse default bold font: %v\n", err))
+		}
+		bold.Name = "Go Bold"
+
+		regular.Bold = bold
+		defaultFont = regular
+	}
+	return defaultFont
+}
+
+// Face : Returns the font rasterized at size pixels per em.  Each size
+// is created once for the current backend.
+func (f *Font) Face(size float64) (*FontFace, error) {
+	if face, ok := f.faces[size]; ok && face.backend == glState.Backend {
+		return face, nil
+	}
+
+	face := &FontFace{
+		Font:    f,
+		Size:    size,
+		Glyphs:  map[rune]*Glyph{},
+		backend: glState.Backend,
+		metrics: map[rune]glyphMetrics{},
+	}
+
+	metrics, err := f.sfnt.Metrics(&face.buffer, face.ppem(), font.HintingNone)
+	if err != nil {
+		return nil, err
+	}
+	face.Ascent = fixedToFloat(metrics.Ascent)
+	face.Descent = fixedToFloat(metrics.Descent)
+	face.LineHeight = fixedToFloat(metrics.Height)
+
+	f.faces[size] = face
+	return face, nil
+}
+
+// HasGlyph : Returns true if the font, not including its fallbacks, has a
+// glyph for the character
+func (f *Font) HasGlyph(char rune) bool {
+	var buffer sfnt.Buffer
+	index, err := f.sfnt.GlyphIndex(&buffer, char)
+	return err == nil && index != 0
+}
+
+// Glyph : Returns the glyph for the character, rasterizing it into the
+// atlas the first time it is drawn.  Characters missing from the font are
+// taken from its fallbacks, or else replaced with U+FFFD or the font's
+// missing glyph symbol.
+func (f *FontFace) Glyph(char rune) *Glyph {
+	if glyph, ok := f.Glyphs[char]; ok {
+		return glyph
+	}
+
+	glyph, err := f.createGlyph(char)
+	if err != nil {
+		log.Warning.Printf("Failed to create glyph %q in %v: %v\n", char, f.Font.Name, err)
+		glyph = &Glyph{Advance: f.Advance(char), font: f.Font}
+	}
+
+	f.Glyphs[char] = glyph
+	return glyph
+}
+
+// Advance : Returns the distance the pen moves after drawing the
+// character, without rasterizing it
+func (f *FontFace) Advance(char rune) float32 {
+	return f.glyphMetrics(char).advance
+}
+
+// Kern : Returns the adjustment to the distance between the pen positions
+// of two adjacent characters.  Characters drawn from different fonts are
+// not kerned.
+func (f *FontFace) Kern(left rune, right rune) float32 {
+	a := f.glyphMetrics(left)
+	b := f.glyphMetrics(right)
+	if a.font != b.font {
+		return 0
+	}
+
+	kern, err := a.font.sfnt.Kern(&f.buffer, a.index, b.index, f.ppem(), font.HintingNone)
+	if err != nil {
+		return 0
+	}
+	return fixedToFloat(kern)
+}
+
+func (f *FontFace) ppem() fixed.Int26_6 {
+	return fixed.Int26_6(math.Round(f.Size * 64))
+}
+
+// glyphMetrics : Returns the font, glyph and advance used for the
+// character
+func (f *FontFace) glyphMetrics(char rune) glyphMetrics {
+	if metrics, ok := f.metrics[char]; ok {
+		return metrics
+	}
+
+	glyphFont, index := f.glyphFont(char)
+	metrics := glyphMetrics{font: glyphFont, index: index}
+
+	advance, err := glyphFont.sfnt.GlyphAdvance(&f.buffer, index, f.ppem(), font.HintingNone)
+	if err != nil {
+		log.Warning.Printf("Failed to find advance of %q in %v: %v\n", char, glyphFont.Name, err)
+	} else {
+		metrics.advance = fixedToFloat(advance)
+	}
+
+	f.metrics[char] = metrics
+	return metrics
+}
+
+// glyphFont : Returns the font in the fallback chain with a glyph for the
+// character, then the font with a replacement character, and otherwise
+// the missing glyph of the face's own font
+func (f *FontFace) glyphFont(char rune) (*Font, sfnt.GlyphIndex) {
+	for _, candidate := range []rune{char, replacementChar} {
+		if found := f.Font.find(candidate, map[*Font]bool{}); found != nil {
+			index, _ := found.sfnt.GlyphIndex(&f.buffer, candidate)
+			return found, index
+		}
+	}
+	return f.Font, 0
+}
+
+// find : Returns the first font in a depth first search of the fallback
+// chain with a glyph for the character, or nil
+func (f *Font) find(char rune, visited map[*Font]bool) *Font {
+	if visited[f] {
+		return nil
+	}
+	visited[f] = true
+
+	if f.HasGlyph(char) {
+		return f
+	}
+	for _, fallback := range f.Fallbacks {
+		if found := fallback.find(char, visited); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// createGlyph : Rasterizes the glyph for the character, adds it to the
+// atlas and creates a renderer drawing its region of the atlas
+func (f *FontFace) createGlyph(char rune) (*Glyph, error) {
+	metrics := f.glyphMetrics(char)
+	glyph := &Glyph{
+		Advance: metrics.advance,
+		font:    metrics.font,
+		index:   metrics.index,
+	}
+
+	mask, bounds, err := f.rasterize(metrics.font, metrics.index)
+	if err != nil || mask == nil {
+		return glyph, err
+	}
+
+	texture, offset, err := f.place(mask)
+	if err != nil {
+		return nil, err
+	}
+
+	renderer, err := CreateMeshRenderer(
+		"ORTHO_VERTEX_SHADER",
+		"TEXT_FRAGMENT_SHADER",
+		[]int{UniformTexture, UniformColor},
+		map[int]interface{}{
+			UniformTexture: texture,
+		},
+		glyphVertices(bounds, mask.Rect.Size(), offset, f.page.size))
+	if err != nil {
+		return nil, err
+	}
+	glyph.Renderer = renderer
+
+	return glyph, nil
+}
+
+// place : Copies the glyph mask into the current atlas, starting a new
+// atlas if it is full.  Returns the atlas and the offset of the glyph.
+func (f *FontFace) place(mask *image.Alpha) (*GLTexture, image.Point, error) {
+	size := mask.Rect.Size()
+	page := &f.page
+
+	if page.texture != nil && page.x+size.X+glyphPadding > page.size.X {
+		page.x = glyphPadding
+		page.y += page.rowHeight + glyphPadding
+		page.rowHeight = 0
+	}
+
+	if page.texture == nil || page.y+size.Y+glyphPadding > page.size.Y {
+		if err := f.addPage(size); err != nil {
+			return nil, image.Point{}, err
+		}
+	}
+
+	offset := image.Point{page.x, page.y}
+	rgba := image.NewRGBA(mask.Rect)
+	draw.DrawMask(rgba, rgba.Rect, image.White, image.Point{}, mask, mask.Rect.Min, draw.Src)
+	backend().UploadSubImage(page.texture, rgba, offset)
+
+	page.x += size.X + glyphPadding
+	if size.Y > page.rowHeight {
+		page.rowHeight = size.Y
+	}
+
+	return page.texture, offset, nil
+}
+
+// addPage : Starts a new, empty atlas large enough for the glyph
+func (f *FontFace) addPage(glyph image.Point) error {
+	side := minAtlasSize
+	for side < maxAtlasSize && float64(side) < f.Size*10 {
+		side *= 2
+	}
+	for side < glyph.X+2*glyphPadding || side < glyph.Y+2*glyphPadding {
+		side *= 2
+	}
+
+	texture, err := backend().CreateTexture(image.NewRGBA(image.Rect(0, 0, side, side)))
+	if err != nil {
+		return err
+	}
+
+	f.Atlases = append(f.Atlases, texture)
+	f.page = atlasPage{
+		texture: texture,
+		size:    image.Point{side, side},
+		x:       glyphPadding,
+		y:       glyphPadding,
+	}
+	return nil
+}
+
+// rasterize : Draws the outline of the glyph into an alpha mask, or
+// returns a nil mask if it has no outline.  Also returns the position of
+// the mask relative to the pen position, with Y increasing downwards.
+func (f *FontFace) rasterize(glyphFont *Font, index sfnt.GlyphIndex) (*image.Alpha, image.Rectangle, error) {
+	segments, err := glyphFont.sfnt.LoadGlyph(&f.buffer, index, f.ppem(), nil)
+	if err != nil {
+		return nil, image.Rectangle{}, err
+	}
+
+	bounds := segmentBounds(segments)
+	if len(segments) == 0 || bounds.Empty() {
+		return nil, bounds, nil
+	}
+
+	size := bounds.Size()
+	rasterizer := vector.NewRasterizer(size.X, size.Y)
+	dx := float32(-bounds.Min.X)
+	dy := float32(-bounds.Min.Y)
+	point := func(p fixed.Point26_6) (float32, float32) {
+		return fixedToFloat(p.X) + dx, fixedToFloat(p.Y) + dy
+	}
+
+	for i, segment := range segments {
+		a := segment.Args
+		switch segment.Op {
+		case sfnt.SegmentOpMoveTo:
+			if i > 0 {
+				rasterizer.ClosePath()
+			}
+			rasterizer.MoveTo(point(a[0]))
+		case sfnt.SegmentOpLineTo:
+			rasterizer.LineTo(point(a[0]))
+		case sfnt.SegmentOpQuadTo:
+			bx, by := point(a[0])
+			cx, cy := point(a[1])
+			rasterizer.QuadTo(bx, by, cx, cy)
+		case sfnt.SegmentOpCubeTo:
+			bx, by := point(a[0])
+			cx, cy := point(a[1])
+			ex, ey := point(a[2])
+			rasterizer.CubeTo(bx, by, cx, cy, ex, ey)
+		}
+	}
+	rasterizer.ClosePath()
+
+	mask := image.NewAlpha(image.Rect(0, 0, size.X, size.Y))
+	rasterizer.Draw(mask, mask.Rect, image.Opaque, image.Point{})
+
+	return mask, bounds, nil
+}
+
+// segmentBounds : Returns the whole pixel bounds of the control points of
+// the segments
+func segmentBounds(segments []sfnt.Segment) image.Rectangle {
+	minX, minY := fixed.Int26_6(math.MaxInt32), fixed.Int26_6(math.MaxInt32)
+	maxX, maxY := fixed.Int26_6(math.MinInt32), fixed.Int26_6(math.MinInt32)
+
+	for _, segment := range segments {
+		count := 1
+		switch segment.Op {
+		case sfnt.SegmentOpQuadTo:
+			count = 2
+		case sfnt.SegmentOpCubeTo:
+			count = 3
+		}
+		for _, p := range segment.Args[:count] {
+			if p.X < minX {
+				minX = p.X
+			}
+			if p.X > maxX {
+				maxX = p.X
+			}
+			if p.Y < minY {
+				minY = p.Y
+			}
+			if p.Y > maxY {
+				maxY = p.Y
+			}
+		}
+	}
+
+	return image.Rect(minX.Floor(), minY.Floor(), maxX.Ceil(), maxY.Ceil())
+}
+
+// glyphVertices : Returns a quad covering the glyph bounds relative to
+// the pen position, with Y flipped to increase upwards, textured with the
+// region of the atlas at offset
+func glyphVertices(bounds image.Rectangle, size image.Point, offset image.Point, atlas image.Point) []float32 {
+	x0 := float32(bounds.Min.X)
+	x1 := float32(bounds.Max.X)
+	y0 := float32(-bounds.Max.Y)
+	y1 := float32(-bounds.Min.Y)
+
+	u0 := float32(offset.X) / float32(atlas.X)
+	u1 := float32(offset.X+size.X) / float32(atlas.X)
+	v0 := float32(offset.Y) / float32(atlas.Y)
+	v1 := float32(offset.Y+size.Y) / float32(atlas.Y)
+
+	return []float32{
+		// Bottom left
+		x0, y0, 0.0, u0, v1,
+		// Top right
+		x1, y1, 0.0, u1, v0,
+		// Top left
+		x0, y1, 0.0, u0, v0,
+		// Bottom left
+		x0, y0, 0.0, u0, v1,
+		// Bottom right
+		x1, y0, 0.0, u1, v1,
+		// Top right
+		x1, y1, 0.0, u1, v0,
+	}
+}
+
+func fixedToFloat(x fixed.Int26_6) float32 {
+	return float32(x) / 64.0
+}
@@ -0,0 +1,132 @@
+package render
+
+import (
+	"testing"
+
+	"golang.org/x/image/font/gofont/gomono"
+)
+
+// TestFontFace : Test that glyphs are only rasterized into the atlas when
+// first used, including characters outside ASCII
+func TestFontFace(t *testing.T) {
+	if _, err := InitSoftware(8, 8); err != nil {
+		t.Fatalf("InitSoftware failed: %v", err)
+	}
+
+	face, err := DefaultFont().Face(20)
+	if err != nil {
+		t.Fatalf("Face failed: %v", err)
+	}
+
+	if len(face.Glyphs) != 0 || len(face.Atlases) != 0 {
+		t.Errorf("Glyphs were (%v) in (%v) atlases should be empty", len(face.Glyphs), len(face.Atlases))
+	}
+	if face.Ascent <= 0 || face.Descent <= 0 || face.LineHeight < face.Ascent+face.Descent {
+		t.Errorf("Metrics were (%v, %v, %v) should be positive with height at least ascent plus descent",
+			face.Ascent, face.Descent, face.LineHeight)
+	}
+
+	for _, char := range "Zoë ñ Ωμέγα" {
+		glyph := face.Glyph(char)
+		if glyph.Advance <= 0 {
+			t.Errorf("Advance of (%q) was (%v) should be positive", char, glyph.Advance)
+		}
+		if (glyph.Renderer == nil) != (char == ' ') {
+			t.Errorf("Renderer of (%q) was (%v)", char, glyph.Renderer)
+		}
+	}
+	if len(face.Atlases) != 1 {
+		t.Errorf("Atlases was (%v) should be (%v)", len(face.Atlases), 1)
+	}
+
+	again, err := DefaultFont().Face(20)
+	if err != nil || again != face {
+		t.Errorf("Face was not reused for the same size")
+	}
+}
+
+// TestFontAtlasPages : Test that a new atlas is started when the current
+// one is full
+func TestFontAtlasPages(t *testing.T) {
+	if _, err := InitSoftware(8, 8); err != nil {
+		t.Fatalf("InitSoftware failed: %v", err)
+	}
+
+	face, err := DefaultFont().Face(64)
+	if err != nil {
+		t.Fatalf("Face failed: %v", err)
+	}
+
+	for char := '!'; char <= 'ɏ'; char++ {
+		face.Glyph(char)
+	}
+
+	if len(face.Atlases) < 2 {
+		t.Errorf("Atlases was (%v) should be at least (%v)", len(face.Atlases), 2)
+	}
+}
+
+// TestFontFallback : Test that characters missing from the font and its
+// fallbacks are drawn with the replacement character, and that a cycle
+// of fallbacks is searched only once
+func TestFontFallback(t *testing.T) {
+	if _, err := InitSoftware(8, 8); err != nil {
+		t.Fatalf("InitSoftware failed: %v", err)
+	}
+
+	primary, err := ParseFont(gomono.TTF)
+	if err != nil {
+		t.Fatalf("ParseFont failed: %v", err)
+	}
+	fallback := DefaultFont()
+	primary.Fallbacks = []*Font{fallback}
+	fallback.Fallbacks = []*Font{primary}
+	defer func() { fallback.Fallbacks = nil }()
+
+	face, err := primary.Face(20)
+	if err != nil {
+		t.Fatalf("Face failed: %v", err)
+	}
+
+	if primary.HasGlyph('中') || fallback.HasGlyph('中') {
+		t.Fatalf("Test fonts should not have a glyph for (中)")
+	}
+
+	missing := face.Glyph('中')
+	replacement := face.Glyph('�')
+	if missing.font != primary || missing.index != replacement.index {
+		t.Errorf("Glyph for (中) was (%v) should be the replacement (%v)", missing.index, replacement.index)
+	}
+	if missing.Renderer == nil || missing.Advance != replacement.Advance {
+		t.Errorf("Replacement glyph should be drawn with advance (%v)", replacement.Advance)
+	}
+
+	if glyph := face.Glyph('é'); glyph.font != primary {
+		t.Errorf("Glyph for (é) should come from the primary font")
+	}
+}
+
+// TestTextWidth : Test that characters are spaced by their advance and
+// kerning, and centered on the origin
+func TestTextWidth(t *testing.T) {
+	if _, err := InitSoftware(8, 8); err != nil {
+		t.Fatalf("InitSoftware failed: %v", err)
+	}
+
+	face, err := DefaultFont().Face(40)
+	if err != nil {
+		t.Fatalf("Face failed: %v", err)
+	}
+
+	renderer := NewTextRenderer(face, "AVé", [4]float32{1, 1, 1, 1})
+	renderer.InitTransforms()
+
+	expected := face.Glyph('A').Advance + face.Glyph('V').Advance + face.Glyph('é').Advance +
+		face.Kern('A', 'V') + face.Kern('V', 'é')
+	if renderer.Size.X() != expected {
+		t.Errorf("Width was (%v) should be (%v)", renderer.Size.X(), expected)
+	}
+	if x := renderer.Glyphs[0].Transform.Col(3).X(); x != -expected/2 {
+		t.Errorf("First pen position was (%v) should be (%v)", x, -expected/2)
+	}
+}
@@ -0,0 +1,97 @@
+package render
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Hittable : Implemented by renderers which can report whether a point in
+// model space falls on the shape they draw
+type Hittable interface {
+	Contains(point mgl32.Vec2) bool
+}
+
+// GetProjection : Returns the current projection matrix
+func GetProjection() mgl32.Mat4 {
+	return glState.Projection
+}
+
+// Contains : Returns true if the point in model space is inside one of
+// the mesh triangles
+func (r *MeshRenderer) Contains(point mgl32.Vec2) bool {
+	v := r.MeshVertices
+	stride := r.Shader.VertexStride()
+	triangle := 3 * stride
+
+	for i := 0; i+triangle <= len(v); i += triangle {
+		a := mgl32.Vec2{v[i], v[i+1]}
+		b := mgl32.Vec2{v[i+stride], v[i+stride+1]}
+		c := mgl32.Vec2{v[i+2*stride], v[i+2*stride+1]}
+
+		if TriangleContains(a, b, c, point) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Contains : Returns true if the point in model space is inside the bitmap
+func (r *BitmapRenderer) Contains(point mgl32.Vec2) bool {
+	return r.MeshRenderer.Contains(point)
+}
+
+// Contains : Returns true if the point in model space is inside the
+// rectangle of the sprite
+func (r *SpriteRenderer) Contains(point mgl32.Vec2) bool {
+	half := r.Size().Mul(0.5)
+	return point[0] >= -half[0] && point[0] <= half[0] &&
+		point[1] >= -half[1] && point[1] <= half[1]
+}
+
+// Contains : Returns true if the point in model space is inside the
+// rectangle of the current frame
+func (r *AnimationRenderer) Contains(point mgl32.Vec2) bool {
+	local := r.frameModel(mgl32.Ident4()).Inv().Mul4x1(point.Vec4(0, 1)).Vec2()
+	return r.Sprite.Contains(local)
+}
+
+// Contains : Returns true if the point in model space is inside one of
+// the rendered characters
+func (r *TextRenderer) Contains(point mgl32.Vec2) bool {
+	if r.Glyphs == nil {
+		r.InitTransforms()
+	}
+
+	for _, placed := range r.Glyphs {
+		local := placed.Transform.Inv().Mul4x1(point.Vec4(0, 1)).Vec2()
+		if placed.Glyph.Contains(local) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Contains : Returns true if the point, relative to the pen position, is
+// inside the glyph
+func (g *Glyph) Contains(point mgl32.Vec2) bool {
+	return g.Renderer != nil && g.Renderer.Contains(point)
+}
+
+// TriangleContains : Returns true if p is inside or on an edge of the
+// triangle abc, in either winding order.  Triangles with no area contain
+// no points.
+func TriangleContains(a mgl32.Vec2, b mgl32.Vec2, c mgl32.Vec2, p mgl32.Vec2) bool {
+	if edgeFunction(a, b, c) == 0 {
+		return false
+	}
+
+	d1 := edgeFunction(a, b, p)
+	d2 := edgeFunction(b, c, p)
+	d3 := edgeFunction(c, a, p)
+
+	hasNeg := d1 < 0 || d2 < 0 || d3 < 0
+	hasPos := d1 > 0 || d2 > 0 || d3 > 0
+
+	return !(hasNeg && hasPos)
+}
@@ -0,0 +1,510 @@
+package render
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo/log"
+	"golang.org/x/image/colornames"
+)
+
+// Alignment : The horizontal position of each line of text relative to
+// the origin
+type Alignment int
+
+const (
+	// AlignCenter : Lines are centered on the origin
+	AlignCenter Alignment = iota
+	// AlignLeft : Lines start at the origin
+	AlignLeft
+	// AlignRight : Lines end at the origin
+	AlignRight
+)
+
+// Anchor : The vertical position of a block of text relative to the origin
+type Anchor int
+
+const (
+	// AnchorBaseline : The baseline of the first line is on the origin
+	AnchorBaseline Anchor = iota
+	// AnchorTop : The top of the first line is on the origin
+	AnchorTop
+	// AnchorMiddle : The block is centered vertically on the origin
+	AnchorMiddle
+	// AnchorBottom : The bottom of the last line is on the origin
+	AnchorBottom
+)
+
+// TextLayout : Controls how text is broken into lines and placed around
+// the origin.  Lines are broken at newlines, and at spaces to keep them
+// within MaxWidth if it is not zero.  Words longer than MaxWidth are
+// broken between characters.  LineSpacing scales the distance between
+// baselines, where zero means 1.  If Markup is set, text may contain
+// [color=c], [size=n] and [b] spans, closed by [/color], [/size] and
+// [/b], where c is any color accepted by ParseColor.  A closing tag ends
+// the innermost open span of its kind, so spans may overlap.  Sizes are
+// limited to MaxMarkupSize.  [[ is drawn as [, and unrecognised tags are
+// drawn as they are written.
+type TextLayout struct {
+	MaxWidth    float32
+	Align       Alignment
+	Anchor      Anchor
+	LineSpacing float32
+	Markup      bool
+}
+
+// PlacedGlyph : A glyph and the transform from the model origin to its
+// pen position.  Color replaces the text color if not nil, with its alpha
+// multiplied by the alpha of the text color.  Embolden is the distance
+// the glyph is drawn a second time to the right, for bold text in a font
+// without a bold font.
+type PlacedGlyph struct {
+	Glyph     *Glyph
+	Transform mgl32.Mat4
+	Color     *mgl32.Vec4
+	Embolden  float32
+}
+
+// fauxBoldFactor : The distance faux bold glyphs are drawn twice apart,
+// as a fraction of the size of the face
+const fauxBoldFactor = 1.0 / 24.0
+
+// textStyle : The style of a span of marked up text.  size is zero for the
+// size of the face the text is laid out in.
+type textStyle struct {
+	color *mgl32.Vec4
+	size  float64
+	bold  bool
+}
+
+// styledRune : A character and the style of the span it is in
+type styledRune struct {
+	char  rune
+	style textStyle
+}
+
+// layoutRune : A character and the face it is drawn in, at the pen
+// position x from the start of its line
+type layoutRune struct {
+	styledRune
+	face    *FontFace
+	x       float32
+	advance float32
+}
+
+// layoutLine : The characters on a line and the offset of the line's
+// baseline from the model origin
+type layoutLine struct {
+	runes   []layoutRune
+	origin  mgl32.Vec2
+	width   float32
+	ascent  float32
+	descent float32
+	height  float32
+}
+
+// LayoutText : Returns the glyphs for the text in the face placed
+// according to the layout, and the size of the block of text
+func LayoutText(face *FontFace, text []rune, layout TextLayout) ([]PlacedGlyph, mgl32.Vec2) {
+	lines, size := layoutLines(face, text, layout)
+
+	glyphs := make([]PlacedGlyph, 0, len(text))
+	for _, line := range lines {
+		for _, r := range line.runes {
+			placed := PlacedGlyph{
+				Glyph:     r.face.Glyph(r.char),
+				Transform: mgl32.Translate3D(line.origin[0]+r.x, line.origin[1], 0),
+				Color:     r.style.color,
+			}
+			if r.style.bold && face.Font.Bold == nil {
+				placed.Embolden = float32(r.face.Size * fauxBoldFactor)
+			}
+			glyphs = append(glyphs, placed)
+		}
+	}
+
+	return glyphs, size
+}
+
+// MeasureText : Returns the width and height of the text in the face laid
+// out according to the layout, without rasterizing any glyphs
+func MeasureText(face *FontFace, text string, layout TextLayout) mgl32.Vec2 {
+	_, size := layoutLines(face, []rune(text), layout)
+	return size
+}
+
+// layoutLines : Breaks the text into lines and places them around the
+// origin, returning the lines and the size of the block
+func layoutLines(face *FontFace, text []rune, layout TextLayout) ([]layoutLine, mgl32.Vec2) {
+	styled := plainText(text)
+	if layout.Markup {
+		styled = parseMarkup(text)
+	}
+
+	faces := map[textStyle]*FontFace{}
+	lines := []layoutLine{}
+	for _, paragraph := range splitParagraphs(styled) {
+		lines = append(lines, wrapParagraph(face, faces, paragraph, layout.MaxWidth)...)
+	}
+
+	spacing := layout.LineSpacing
+	if spacing == 0 {
+		spacing = 1
+	}
+
+	var baseline, width float32
+	for i := range lines {
+		line := &lines[i]
+		line.measure(face)
+		if i > 0 {
+			baseline -= line.height * spacing
+		}
+		line.origin = mgl32.Vec2{alignOffset(line.width, layout.Align), baseline}
+		if line.width > width {
+			width = line.width
+		}
+	}
+
+	top := lines[0].ascent
+	bottom := baseline - lines[len(lines)-1].descent
+
+	var shift float32
+	switch layout.Anchor {
+	case AnchorTop:
+		shift = -top
+	case AnchorMiddle:
+		shift = -(top + bottom) / 2
+	case AnchorBottom:
+		shift = -bottom
+	}
+	for i := range lines {
+		lines[i].origin[1] += shift
+	}
+
+	return lines, mgl32.Vec2{width, top - bottom}
+}
+
+// splitParagraphs : Splits the text at newlines.  There is always at
+// least one paragraph, which may be empty.
+func splitParagraphs(text []styledRune) [][]styledRune {
+	paragraphs := [][]styledRune{}
+	start := 0
+	for i, r := range text {
+		if r.char == '\n' {
+			paragraphs = append(paragraphs, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(paragraphs, text[start:])
+}
+
+// wrapParagraph : Places the characters of a paragraph along lines no
+// wider than maxWidth, breaking after the last space that fits, or
+// between characters if a word does not fit on a line by itself.  Spaces
+// at the end of a line do not count towards its width.
+func wrapParagraph(base *FontFace, faces map[textStyle]*FontFace, paragraph []styledRune, maxWidth float32) []layoutLine {
+	lines := []layoutLine{}
+	line := []layoutRune{}
+	// breakAt : The index of the character after the last space in the
+	// line, or -1 if there is no space to break at
+	breakAt := -1
+
+	for _, styled := range paragraph {
+		face := styleFace(base, faces, styled.style)
+		item := layoutRune{styledRune: styled, face: face, advance: face.Advance(styled.char)}
+
+		for maxWidth > 0 && styled.char != ' ' && len(line) > 0 &&
+			penAfter(line, item)+item.advance > maxWidth {
+			if breakAt > 0 {
+				lines = append(lines, layoutLine{runes: line[:breakAt]})
+				line = repositioned(line[breakAt:])
+				breakAt = -1
+			} else {
+				lines = append(lines, layoutLine{runes: line})
+				line = []layoutRune{}
+			}
+		}
+
+		item.x = penAfter(line, item)
+		line = append(line, item)
+		if styled.char == ' ' {
+			breakAt = len(line)
+		}
+	}
+
+	return append(lines, layoutLine{runes: line})
+}
+
+// penAfter : Returns the pen position of the item if it were added to the
+// end of the line.  Characters in the same face are kerned.
+func penAfter(line []layoutRune, item layoutRune) float32 {
+	if len(line) == 0 {
+		return 0
+	}
+	last := line[len(line)-1]
+	x := last.x + last.advance
+	if last.face == item.face {
+		x += item.face.Kern(last.char, item.char)
+	}
+	return x
+}
+
+// repositioned : Returns the characters with pen positions recalculated
+// for the start of a new line
+func repositioned(runes []layoutRune) []layoutRune {
+	line := make([]layoutRune, 0, len(runes))
+	for _, item := range runes {
+		item.x = penAfter(line, item)
+		line = append(line, item)
+	}
+	return line
+}
+
+// measure : Calculates the width of the line, not including trailing
+// spaces, and its vertical metrics from the largest face on the line.
+// Empty lines take their metrics from the base face.
+func (l *layoutLine) measure(base *FontFace) {
+	l.width = 0
+	for i := len(l.runes) - 1; i >= 0; i-- {
+		if l.runes[i].char != ' ' {
+			l.width = l.runes[i].x + l.runes[i].advance
+			break
+		}
+	}
+
+	faces := []*FontFace{base}
+	if len(l.runes) > 0 {
+		faces = faces[:0]
+		for _, r := range l.runes {
+			faces = append(faces, r.face)
+		}
+	}
+
+	for _, face := range faces {
+		if face.Ascent > l.ascent {
+			l.ascent = face.Ascent
+		}
+		if face.Descent > l.descent {
+			l.descent = face.Descent
+		}
+		if face.LineHeight > l.height {
+			l.height = face.LineHeight
+		}
+	}
+}
+
+// alignOffset : Returns the offset of the start of a line of the width
+// from the origin
+func alignOffset(width float32, align Alignment) float32 {
+	switch align {
+	case AlignLeft:
+		return 0
+	case AlignRight:
+		return -width
+	}
+	return -width / 2
+}
+
+// styleFace : Returns the face used to draw characters in the style,
+// which is the base face unless the style changes the size or is bold
+func styleFace(base *FontFace, faces map[textStyle]*FontFace, style textStyle) *FontFace {
+	key := textStyle{size: style.size, bold: style.bold}
+	if face, ok := faces[key]; ok {
+		return face
+	}
+
+	size := base.Size
+	if style.size > 0 {
+		size = style.size
+	}
+	font := base.Font
+	if style.bold {
+		font = boldFont(font)
+	}
+
+	face := base
+	if font != base.Font || size != base.Size {
+		var err error
+		face, err = font.Face(size)
+		if err != nil {
+			log.Warning.Printf("Failed to create face for %v at size %v: %v\n", font.Name, size, err)
+			face = base
+		}
+	}
+
+	faces[key] = face
+	return face
+}
+
+// boldFont : Returns the font used for bold text in the font
+func boldFont(font *Font) *Font {
+	if font.Bold != nil {
+		return font.Bold
+	}
+	return font
+}
+
+// plainText : Returns the text with every character in the default style
+func plainText(text []rune) []styledRune {
+	styled := make([]styledRune, len(text))
+	for i, char := range text {
+		styled[i] = styledRune{char: char}
+	}
+	return styled
+}
+
+// MaxMarkupSize : The largest text size which may be set by a [size=n]
+// markup span.  Larger sizes are reduced to it.
+const MaxMarkupSize = 256
+
+// markupSpan : An open markup span and the tag which opened it
+type markupSpan struct {
+	name string
+	tag  string
+}
+
+// parseMarkup : Returns the characters of text with markup tags removed,
+// each with the style of the spans it is in.  See TextLayout.
+func parseMarkup(text []rune) []styledRune {
+	styled := make([]styledRune, 0, len(text))
+	spans := []markupSpan{}
+	current := textStyle{}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] != '[' {
+			styled = append(styled, styledRune{char: text[i], style: current})
+			continue
+		}
+		if i+1 < len(text) && text[i+1] == '[' {
+			styled = append(styled, styledRune{char: '[', style: current})
+			i++
+			continue
+		}
+
+		end := i + 1
+		for end < len(text) && text[end] != ']' && text[end] != '[' {
+			end++
+		}
+		if end == len(text) || text[end] != ']' {
+			styled = append(styled, styledRune{char: '[', style: current})
+			continue
+		}
+
+		tag := string(text[i+1 : end])
+		if strings.HasPrefix(tag, "/") {
+			if j := innermostSpan(spans, tag[1:]); j >= 0 {
+				spans = append(spans[:j], spans[j+1:]...)
+				current = spanStyle(spans)
+				i = end
+				continue
+			}
+		} else if style, ok := openSpan(tag, current); ok {
+			name, _ := splitTag(tag)
+			spans = append(spans, markupSpan{name: name, tag: tag})
+			current = style
+			i = end
+			continue
+		}
+
+		styled = append(styled, styledRune{char: '[', style: current})
+	}
+
+	return styled
+}
+
+// splitTag : Returns the name of a markup tag and the value after any =
+func splitTag(tag string) (string, string) {
+	if eq := strings.IndexByte(tag, '='); eq >= 0 {
+		return tag[:eq], tag[eq+1:]
+	}
+	return tag, ""
+}
+
+// openSpan : Returns the style inside a span opened by the tag within the
+// current style, or false if the tag is not recognised
+func openSpan(tag string, current textStyle) (textStyle, bool) {
+	name, value := splitTag(tag)
+
+	switch name {
+	case "b":
+		if value == "" {
+			current.bold = true
+			return current, true
+		}
+	case "color":
+		if color, ok := ParseColor(value); ok {
+			current.color = &color
+			return current, true
+		}
+	case "size":
+		if size, err := strconv.ParseFloat(value, 64); err == nil && size > 0 {
+			current.size = math.Min(size, MaxMarkupSize)
+			return current, true
+		}
+	}
+	return current, false
+}
+
+// innermostSpan : Returns the index of the last open span with the name,
+// or -1 if there is none
+func innermostSpan(spans []markupSpan, name string) int {
+	for i := len(spans) - 1; i >= 0; i-- {
+		if spans[i].name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// spanStyle : Returns the style inside the open spans
+func spanStyle(spans []markupSpan) textStyle {
+	style := textStyle{}
+	for _, span := range spans {
+		style, _ = openSpan(span.tag, style)
+	}
+	return style
+}
+
+// ParseColor : Returns the color given by an SVG color name or a hex
+// color in the form #rgb, #rgba, #rrggbb or #rrggbbaa
+func ParseColor(value string) (mgl32.Vec4, bool) {
+	if c, ok := colornames.Map[strings.ToLower(value)]; ok {
+		return mgl32.Vec4{
+			float32(c.R) / 255.0,
+			float32(c.G) / 255.0,
+			float32(c.B) / 255.0,
+			float32(c.A) / 255.0,
+		}, true
+	}
+
+	if !strings.HasPrefix(value, "#") {
+		return mgl32.Vec4{}, false
+	}
+	hex := value[1:]
+	if len(hex) == 3 || len(hex) == 4 {
+		expanded := make([]byte, 0, 2*len(hex))
+		for i := 0; i < len(hex); i++ {
+			expanded = append(expanded, hex[i], hex[i])
+		}
+		hex = string(expanded)
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return mgl32.Vec4{}, false
+	}
+
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return mgl32.Vec4{}, false
+	}
+	return mgl32.Vec4{
+		float32(n>>24&0xff) / 255.0,
+		float32(n>>16&0xff) / 255.0,
+		float32(n>>8&0xff) / 255.0,
+		float32(n&0xff) / 255.0,
+	}, true
+}
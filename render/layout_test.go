@@ -0,0 +1,224 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"golang.org/x/image/font/gofont/gomono"
+)
+
+// monoFace : Returns a face of the Go Mono font, in which every character
+// has the same advance
+func monoFace(t *testing.T, size float64) *FontFace {
+	if _, err := InitSoftware(8, 8); err != nil {
+		t.Fatalf("InitSoftware failed: %v", err)
+	}
+
+	font, err := ParseFont(gomono.TTF)
+	if err != nil {
+		t.Fatalf("ParseFont failed: %v", err)
+	}
+	face, err := font.Face(size)
+	if err != nil {
+		t.Fatalf("Face failed: %v", err)
+	}
+	return face
+}
+
+var wrapTests = []struct {
+	name     string
+	text     string
+	maxWidth int
+	lines    []string
+}{
+	{"unlimited", "the quick brown fox", 0, []string{"the quick brown fox"}},
+	{"at spaces", "the quick brown fox", 10, []string{"the quick ", "brown fox"}},
+	{"newlines", "the\n\nfox", 0, []string{"the", "", "fox"}},
+	{"long word", "abcdefghij klm", 4, []string{"abcd", "efgh", "ij ", "klm"}},
+	{"trailing space fits", "ab cd", 3, []string{"ab ", "cd"}},
+}
+
+// TestWrapText : Test that lines are broken at newlines and at the last
+// space which fits within the maximum width
+func TestWrapText(t *testing.T) {
+	face := monoFace(t, 20)
+	advance := face.Advance('m')
+
+	for _, tc := range wrapTests {
+		t.Run(tc.name, func(t *testing.T) {
+			lines, _ := layoutLines(face, []rune(tc.text),
+				TextLayout{MaxWidth: float32(tc.maxWidth) * advance})
+
+			actual := []string{}
+			for _, line := range lines {
+				chars := []rune{}
+				for _, r := range line.runes {
+					chars = append(chars, r.char)
+				}
+				actual = append(actual, string(chars))
+			}
+
+			if len(actual) != len(tc.lines) {
+				t.Fatalf("Lines were (%q) should be (%q)", actual, tc.lines)
+			}
+			for i := range actual {
+				if actual[i] != tc.lines[i] {
+					t.Errorf("Lines were (%q) should be (%q)", actual, tc.lines)
+					break
+				}
+			}
+		})
+	}
+}
+
+var alignTests = []struct {
+	name   string
+	align  Alignment
+	anchor Anchor
+	x      float32
+	y      func(face *FontFace) float32
+}{
+	{"center baseline", AlignCenter, AnchorBaseline, -2.5, func(f *FontFace) float32 { return 0 }},
+	{"left top", AlignLeft, AnchorTop, 0, func(f *FontFace) float32 { return -f.Ascent }},
+	{"right bottom", AlignRight, AnchorBottom, -5, func(f *FontFace) float32 { return f.LineHeight + f.Descent }},
+	{"left middle", AlignLeft, AnchorMiddle, 0,
+		func(f *FontFace) float32 { return (f.LineHeight + f.Descent - f.Ascent) / 2 }},
+}
+
+// TestAlignText : Test the position of the first line for each alignment
+// and vertical anchor
+func TestAlignText(t *testing.T) {
+	face := monoFace(t, 20)
+	advance := face.Advance('m')
+
+	for _, tc := range alignTests {
+		t.Run(tc.name, func(t *testing.T) {
+			lines, _ := layoutLines(face, []rune("hello\nhi"),
+				TextLayout{Align: tc.align, Anchor: tc.anchor})
+
+			expected := mgl32.Vec2{tc.x * advance, tc.y(face)}
+			if !lines[0].origin.ApproxEqualThreshold(expected, 1e-3) {
+				t.Errorf("Origin was (%v) should be (%v)", lines[0].origin, expected)
+			}
+			if lines[0].origin[1]-lines[1].origin[1] != face.LineHeight {
+				t.Errorf("Line distance was (%v) should be (%v)",
+					lines[0].origin[1]-lines[1].origin[1], face.LineHeight)
+			}
+		})
+	}
+}
+
+var markupTests = []struct {
+	name  string
+	text  string
+	plain string
+	bold  []bool
+}{
+	{"no tags", "abc", "abc", []bool{false, false, false}},
+	{"bold span", "a[b]b[/b]c", "abc", []bool{false, true, false}},
+	{"escaped", "[[b]x", "[b]x", []bool{false, false, false, false}},
+	{"unknown tag", "[i]x[/i]", "[i]x[/i]", nil},
+	{"unmatched close", "x[/b]", "x[/b]", nil},
+	{"unterminated", "[b", "[b", nil},
+	{"nested", "[color=red][b]x[/b]y[/color]", "xy", []bool{true, false}},
+	{"overlapping", "[b][color=red]x[/b]y[/color]z", "xyz", []bool{true, false, false}},
+}
+
+// TestParseMarkup : Test that recognised tags are removed and style the
+// characters between them
+func TestParseMarkup(t *testing.T) {
+	for _, tc := range markupTests {
+		t.Run(tc.name, func(t *testing.T) {
+			styled := parseMarkup([]rune(tc.text))
+
+			chars := []rune{}
+			for _, r := range styled {
+				chars = append(chars, r.char)
+			}
+			if string(chars) != tc.plain {
+				t.Fatalf("Text was (%q) should be (%q)", string(chars), tc.plain)
+			}
+
+			for i, bold := range tc.bold {
+				if styled[i].style.bold != bold {
+					t.Errorf("Bold of (%q) was (%v) should be (%v)", styled[i].char, styled[i].style.bold, bold)
+				}
+			}
+		})
+	}
+
+	styled := parseMarkup([]rune("[size=30][color=#f008]x[/color][/size]"))
+	if styled[0].style.size != 30 {
+		t.Errorf("Size was (%v) should be (%v)", styled[0].style.size, 30)
+	}
+	expected := mgl32.Vec4{1, 0, 0, float32(0x88) / 255.0}
+	if styled[0].style.color == nil || *styled[0].style.color != expected {
+		t.Errorf("Color was (%v) should be (%v)", styled[0].style.color, expected)
+	}
+
+	// Closing an outer span leaves the inner span's style
+	styled = parseMarkup([]rune("[b][color=red]x[/b]y[/color]z"))
+	if styled[1].style.color == nil || styled[2].style.color != nil {
+		t.Errorf("Colors were (%v, %v) should be red then none", styled[1].style.color, styled[2].style.color)
+	}
+
+	styled = parseMarkup([]rune("[size=100000]x"))
+	if styled[0].style.size != MaxMarkupSize {
+		t.Errorf("Size was (%v) should be (%v)", styled[0].style.size, MaxMarkupSize)
+	}
+}
+
+var colorTests = []struct {
+	value    string
+	expected mgl32.Vec4
+	ok       bool
+}{
+	{"red", mgl32.Vec4{1, 0, 0, 1}, true},
+	{"White", mgl32.Vec4{1, 1, 1, 1}, true},
+	{"#00f", mgl32.Vec4{0, 0, 1, 1}, true},
+	{"#00ff0000", mgl32.Vec4{0, 1, 0, 0}, true},
+	{"#12345", mgl32.Vec4{}, false},
+	{"notacolor", mgl32.Vec4{}, false},
+}
+
+// TestParseColor : Test color names and hex colors
+func TestParseColor(t *testing.T) {
+	for _, tc := range colorTests {
+		color, ok := ParseColor(tc.value)
+		if ok != tc.ok || color != tc.expected {
+			t.Errorf("Color of (%v) was (%v, %v) should be (%v, %v)", tc.value, color, ok, tc.expected, tc.ok)
+		}
+	}
+}
+
+// TestMeasureText : Test that text is measured without rasterizing it,
+// and matches the size of the rendered layout
+func TestMeasureText(t *testing.T) {
+	face := monoFace(t, 20)
+	layout := TextLayout{MaxWidth: 200, Markup: true}
+	text := "[size=30]Game[/size] Over, try again"
+
+	size := MeasureText(face, text, layout)
+	if len(face.Glyphs) != 0 {
+		t.Errorf("Glyphs were (%v) should be empty", len(face.Glyphs))
+	}
+
+	larger, err := face.Font.Face(30)
+	if err != nil {
+		t.Fatalf("Face failed: %v", err)
+	}
+	expected := larger.Ascent + face.LineHeight + face.Descent
+	if size.Y() != expected {
+		t.Errorf("Height was (%v) should be (%v)", size.Y(), expected)
+	}
+	if size.X() > layout.MaxWidth {
+		t.Errorf("Width was (%v) should be at most (%v)", size.X(), layout.MaxWidth)
+	}
+
+	renderer := NewTextRenderer(face, text, mgl32.Vec4{1, 1, 1, 1})
+	renderer.SetLayout(layout)
+	renderer.InitTransforms()
+	if renderer.Size != size {
+		t.Errorf("Rendered size was (%v) should be (%v)", renderer.Size, size)
+	}
+}
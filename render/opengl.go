@@ -0,0 +1,291 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"strings"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo/log"
+)
+
+// OpenGLBackend : Renders using OpenGL 4.1 core profile.  Requires a
+// current OpenGL context, for example from CreateWindow.  The program in
+// use and the texture bound to each texture unit are remembered so that
+// drawing many meshes from the same program and texture, such as sprites
+// packed into an atlas, does not rebind them.
+type OpenGLBackend struct {
+	NextTextureUnit int32
+	program         uint32
+	textures        map[int32]uint32
+}
+
+var (
+	glUniformLocProjection = gl.Str("projection\x00")
+	glUniformLocModel      = gl.Str("model\x00")
+	glFragLocOutputColor   = gl.Str("outputColor\x00")
+)
+
+var (
+	glAttribLocVertex         = gl.Str("vert\x00")
+	glAttribLocVertexTexCoord = gl.Str("vertTexCoord\x00")
+	glAttribLocVertexColor    = gl.Str("vertColor\x00")
+)
+
+// InitOpenGL : Initialises OpenGL as the rendering backend
+func InitOpenGL() error {
+	return Init(&OpenGLBackend{})
+}
+
+func (b *OpenGLBackend) Init() error {
+	// Initialize Glow
+	if err := gl.Init(); err != nil {
+		log.Error.Println("gl.Init failed:", err)
+		return err
+	}
+
+	version := gl.GoStr(gl.GetString(gl.VERSION))
+	log.Trace.Println("OpenGL version:", version)
+
+	// Configure global settings
+	gl.Enable(gl.BLEND)
+	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+	gl.ClearColor(0.0, 0.0, 0.0, 1.0)
+
+	return nil
+}
+
+func (b *OpenGLBackend) Clear() {
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+}
+
+func (b *OpenGLBackend) CompileProgram(vertexShader string, fragmentShader string) (*GLShader, error) {
+	shader, err := loadProgram(shaders[vertexShader], shaders[fragmentShader])
+	if err != nil {
+		return nil, err
+	}
+
+	gl.UseProgram(shader.Program)
+	b.program = shader.Program
+	gl.BindFragDataLocation(shader.Program, 0, glFragLocOutputColor)
+
+	shader.Projection = gl.GetUniformLocation(shader.Program, glUniformLocProjection)
+	shader.Model = gl.GetUniformLocation(shader.Program, glUniformLocModel)
+
+	for uniform, name := range shaderUniforms {
+		shader.Uniforms[uniform] = gl.GetUniformLocation(shader.Program, gl.Str(name+"\x00"))
+	}
+
+	return shader, nil
+}
+
+func (b *OpenGLBackend) CreateMeshBuffer(shader *GLShader, vertices []float32) (uint32, error) {
+	// Configure the vertex data
+	var vao uint32
+	gl.GenVertexArrays(1, &vao)
+	gl.BindVertexArray(vao)
+
+	var vbo uint32
+	gl.GenBuffers(1, &vbo)
+	gl.BindBuffer(gl.ARRAY_BUFFER, vbo)
+	gl.BufferData(
+		gl.ARRAY_BUFFER,
+		len(vertices)*4,
+		gl.Ptr(vertices),
+		gl.STATIC_DRAW)
+
+	strideBytes := int32(shader.VertexStride() * float32SizeBytes)
+
+	vertAttrib := uint32(gl.GetAttribLocation(shader.Program, glAttribLocVertex))
+	gl.EnableVertexAttribArray(vertAttrib)
+	gl.VertexAttribPointer(vertAttrib, 3, gl.FLOAT, false, strideBytes,
+		gl.PtrOffset(0))
+
+	texCoordAttrib := uint32(gl.GetAttribLocation(shader.Program, glAttribLocVertexTexCoord))
+	gl.EnableVertexAttribArray(texCoordAttrib)
+	gl.VertexAttribPointer(texCoordAttrib, 2, gl.FLOAT, false, strideBytes,
+		gl.PtrOffset(3*float32SizeBytes))
+
+	if shader.VertexStride() == GlColorMeshStride {
+		colorAttrib := uint32(gl.GetAttribLocation(shader.Program, glAttribLocVertexColor))
+		gl.EnableVertexAttribArray(colorAttrib)
+		gl.VertexAttribPointer(colorAttrib, 4, gl.FLOAT, false, strideBytes,
+			gl.PtrOffset(GlMeshStride*float32SizeBytes))
+	}
+
+	return vao, nil
+}
+
+func (b *OpenGLBackend) CreateTexture(rgba *image.RGBA) (*GLTexture, error) {
+	if rgba.Stride != rgba.Rect.Size().X*4 {
+		return nil, fmt.Errorf("unsupported image stride")
+	}
+
+	id := TextureFromRGBA(rgba, gl.TEXTURE0)
+	b.boundTexture(0, id)
+
+	return &GLTexture{
+		ID:   id,
+		Size: [2]uint32{uint32(rgba.Rect.Size().X), uint32(rgba.Rect.Size().Y)},
+	}, nil
+}
+
+func (b *OpenGLBackend) UploadSubImage(texture *GLTexture, rgba *image.RGBA, offset image.Point) {
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, texture.ID)
+	b.boundTexture(0, texture.ID)
+	gl.PixelStorei(gl.UNPACK_ROW_LENGTH, int32(rgba.Stride/4))
+	gl.TexSubImage2D(
+		gl.TEXTURE_2D,
+		0,
+		int32(offset.X),
+		int32(offset.Y),
+		int32(rgba.Rect.Size().X),
+		int32(rgba.Rect.Size().Y),
+		gl.RGBA,
+		gl.UNSIGNED_BYTE,
+		gl.Ptr(rgba.Pix))
+	gl.PixelStorei(gl.UNPACK_ROW_LENGTH, 0)
+}
+
+func (b *OpenGLBackend) BindUniforms(
+	shader *GLShader,
+	model mgl32.Mat4,
+	projection mgl32.Mat4,
+	uniforms map[int]interface{},
+) {
+	if shader.Program != b.program {
+		gl.UseProgram(shader.Program)
+		b.program = shader.Program
+	}
+	gl.UniformMatrix4fv(shader.Model, 1, false, &model[0])
+	gl.UniformMatrix4fv(shader.Projection, 1, false, &projection[0])
+
+	b.NextTextureUnit = 0
+	for location, value := range uniforms {
+		b.bindUniform(shader, location, value)
+	}
+}
+
+func (b *OpenGLBackend) bindUniform(
+	shader *GLShader, location int, value interface{},
+) {
+	switch tValue := value.(type) {
+	case *GLTexture:
+		unit := b.NextTextureUnit
+		gl.Uniform1i(shader.Uniforms[location], unit)
+		if b.textures[unit] != tValue.ID {
+			gl.ActiveTexture(gl.TEXTURE0 + uint32(unit))
+			gl.BindTexture(gl.TEXTURE_2D, tValue.ID)
+			b.boundTexture(unit, tValue.ID)
+		}
+		b.NextTextureUnit++
+	case int32:
+		gl.Uniform1i(shader.Uniforms[location], tValue)
+	case float32:
+		gl.Uniform1f(shader.Uniforms[location], tValue)
+	case float64:
+		gl.Uniform1f(shader.Uniforms[location], float32(tValue))
+	case mgl32.Vec4:
+		gl.Uniform4fv(shader.Uniforms[location], 1, &tValue[0])
+	default:
+		panic(fmt.Sprintf("Unhandled uniform(%v) value type: %T\n", location, value))
+	}
+}
+
+// boundTexture : Records the texture bound to the texture unit
+func (b *OpenGLBackend) boundTexture(unit int32, id uint32) {
+	if b.textures == nil {
+		b.textures = map[int32]uint32{}
+	}
+	b.textures[unit] = id
+}
+
+func (b *OpenGLBackend) Draw(shader *GLShader, mesh uint32, vertexCount int32) {
+	gl.BindVertexArray(mesh)
+	gl.DrawArrays(gl.TRIANGLES, 0, vertexCount)
+}
+
+func loadProgram(vertexShaderSource, fragmentShaderSource string) (*GLShader, error) {
+	vertexShader, err := compileShader(vertexShaderSource, gl.VERTEX_SHADER)
+	if err != nil {
+		return nil, err
+	}
+
+	fragmentShader, err := compileShader(fragmentShaderSource, gl.FRAGMENT_SHADER)
+	if err != nil {
+		return nil, err
+	}
+
+	program := gl.CreateProgram()
+
+	gl.AttachShader(program, vertexShader)
+	gl.AttachShader(program, fragmentShader)
+	gl.LinkProgram(program)
+
+	var status int32
+	gl.GetProgramiv(program, gl.LINK_STATUS, &status)
+	if status == gl.FALSE {
+		var logLength int32
+		gl.GetProgramiv(program, gl.INFO_LOG_LENGTH, &logLength)
+
+		log := strings.Repeat("\x00", int(logLength+1))
+		gl.GetProgramInfoLog(program, logLength, nil, gl.Str(log))
+
+		return nil, fmt.Errorf("failed to link program: %v", log)
+	}
+
+	gl.DeleteShader(vertexShader)
+	gl.DeleteShader(fragmentShader)
+
+	return &GLShader{
+		Program:  program,
+		Uniforms: map[int]int32{},
+	}, nil
+}
+
+func compileShader(source string, shaderType uint32) (uint32, error) {
+	shader := gl.CreateShader(shaderType)
+
+	csources, free := gl.Strs(source)
+	gl.ShaderSource(shader, 1, csources, nil)
+	free()
+	gl.CompileShader(shader)
+
+	var status int32
+	gl.GetShaderiv(shader, gl.COMPILE_STATUS, &status)
+	if status == gl.FALSE {
+		var logLength int32
+		gl.GetShaderiv(shader, gl.INFO_LOG_LENGTH, &logLength)
+
+		log := strings.Repeat("\x00", int(logLength+1))
+		gl.GetShaderInfoLog(shader, logLength, nil, gl.Str(log))
+
+		return 0, fmt.Errorf("failed to compile %v: %v", log, source)
+	}
+
+	return shader, nil
+}
+
+func TextureFromRGBA(rgba *image.RGBA, textureUnit uint32) uint32 {
+	var texture uint32
+	gl.GenTextures(1, &texture)
+	gl.ActiveTexture(textureUnit)
+	gl.BindTexture(gl.TEXTURE_2D, texture)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+	gl.TexImage2D(
+		gl.TEXTURE_2D,
+		0,
+		gl.RGBA,
+		int32(rgba.Rect.Size().X),
+		int32(rgba.Rect.Size().Y),
+		0,
+		gl.RGBA,
+		gl.UNSIGNED_BYTE,
+		gl.Ptr(rgba.Pix))
+	return texture
+}
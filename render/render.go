@@ -6,18 +6,21 @@ import (
 	"image/color"
 	"math/rand"
 
-	"github.com/go-gl/gl/v4.1-core/gl"
 	"github.com/go-gl/mathgl/mgl32"
 	"github.com/leedenison/gologo/log"
 	"github.com/leedenison/gologo/time"
 )
 
-// GLState : Stores the shaders, textures, and projection
+// GLState : Stores the backend, shaders, textures, atlas regions, fonts
+// and projection.  Dispatch is set by SetDispatcher.
 type GLState struct {
-	Shaders         map[string]*GLShader
-	Textures        map[string]*GLTexture
-	NextTextureUnit int32
-	Projection      mgl32.Mat4
+	Backend    Backend
+	Dispatch   func(fn func())
+	Shaders    map[string]*GLShader
+	Textures   map[string]*GLTexture
+	Regions    map[string]TextureRegion
+	Fonts      map[string]*Font
+	Projection mgl32.Mat4
 }
 
 type Renderer interface {
@@ -33,9 +36,14 @@ type Renderer interface {
 // Rendering globals
 //
 
+// GlMeshStride : The number of floats per mesh vertex, which are the X, Y
+// and Z position followed by the U and V texture co-ordinates.
+// GlColorMeshStride : The number of floats per mesh vertex for
+// COLOR_VERTEX_SHADER, which appends the red, green, blue and alpha of the
+// vertex color to the GlMeshStride layout.
 const (
 	GlMeshStride      = 5
-	glMeshStrideBytes = GlMeshStride * float32SizeBytes
+	GlColorMeshStride = 9
 )
 
 var CreateMeshRenderer func(
@@ -48,64 +56,71 @@ var CreateMeshRenderer func(
 var CreateTexture func(texturePath string) (*GLTexture, error)
 
 var glState = &GLState{
-	Shaders:         map[string]*GLShader{},
-	Textures:        map[string]*GLTexture{},
-	NextTextureUnit: gl.TEXTURE0,
-}
-
-func InitOpenGL() error {
-	// Initialize Glow
-	if err := gl.Init(); err != nil {
-		log.Error.Println("gl.Init failed:", err)
-		return err
-	}
-
-	CreateMeshRenderer = CreateMeshRendererImpl
-	CreateTexture = CreateTextureImpl
-
-	version := gl.GoStr(gl.GetString(gl.VERSION))
-	log.Trace.Println("OpenGL version:", version)
-
-	// Configure global settings
-	gl.Enable(gl.BLEND)
-	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
-	gl.ClearColor(0.0, 0.0, 0.0, 1.0)
-
-	return nil
+	Shaders:  map[string]*GLShader{},
+	Textures: map[string]*GLTexture{},
+	Regions:  map[string]TextureRegion{},
+	Fonts:    map[string]*Font{},
 }
 
 func ClearBackBuffer() {
-	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+	backend().Clear()
 }
 
 func Set2DProjection(width float32, height float32) {
 	glState.Projection = mgl32.Ortho2D(0, width, 0, height)
 }
 
+// Colors : Returns count colors evenly spaced along the gradient, starting
+// at offset 0.  See GradientColor for the format of gradients.
 func Colors(gradients []float64, count int) []color.RGBA {
+	result := []color.RGBA{}
+
+	for i := 0; i < count; i++ {
+		result = append(result, GradientColor(gradients, float64(i)/float64(count)))
+	}
+
+	return result
+}
+
+// GradientColor : Returns the color at offset q along the gradient.
+// gradients holds the stops of the gradient as groups of four values:
+// the offset of the stop from 0 to 1, followed by its red, green and blue
+// from 0 to 255.  Stops must be in order of increasing offset.  Offsets
+// before the first stop or after the last have the color of that stop.
+func GradientColor(gradients []float64, q float64) color.RGBA {
 	stride := 4
 	red := 1
 	green := 2
 	blue := 3
 
-	result := []color.RGBA{}
+	last := len(gradients)/stride*stride - stride
+	if last < 0 {
+		return color.RGBA{0, 0, 0, 255}
+	}
 
-	for i := 0; i < count; i++ {
-		q := float64(i) / float64(count)
-
-		for j := stride; j < len(gradients); j += stride {
-			if q < gradients[j] {
-				p := q - gradients[j-stride]
-				r := uint8((gradients[j+red]-gradients[j-stride+red])*p + gradients[j-stride+red])
-				g := uint8((gradients[j+green]-gradients[j-stride+green])*p + gradients[j-stride+green])
-				b := uint8((gradients[j+blue]-gradients[j-stride+blue])*p + gradients[j-stride+blue])
-				result = append(result, color.RGBA{r, g, b, 255})
+	for j := 0; j <= last; j += stride {
+		if q < gradients[j] {
+			if j == 0 {
 				break
 			}
+			p := (q - gradients[j-stride]) / (gradients[j] - gradients[j-stride])
+			r := uint8((gradients[j+red]-gradients[j-stride+red])*p + gradients[j-stride+red] + 0.5)
+			g := uint8((gradients[j+green]-gradients[j-stride+green])*p + gradients[j-stride+green] + 0.5)
+			b := uint8((gradients[j+blue]-gradients[j-stride+blue])*p + gradients[j-stride+blue] + 0.5)
+			return color.RGBA{r, g, b, 255}
 		}
 	}
 
-	return result
+	j := last
+	if q < gradients[0] {
+		j = 0
+	}
+	return color.RGBA{
+		uint8(gradients[j+red] + 0.5),
+		uint8(gradients[j+green] + 0.5),
+		uint8(gradients[j+blue] + 0.5),
+		255,
+	}
 }
 
 /////////////////////////////////////////////////////////////
@@ -139,18 +154,7 @@ func (r *BitmapRenderer) Animate(model mgl32.Mat4) {
 		return
 	}
 
-	gl.ActiveTexture(gl.TEXTURE0)
-	gl.BindTexture(gl.TEXTURE_2D, texture.ID)
-	gl.TexSubImage2D(
-		gl.TEXTURE_2D,
-		0,
-		0,
-		0,
-		int32(r.Buffer.Rect.Size().X),
-		int32(r.Buffer.Rect.Size().Y),
-		gl.RGBA,
-		gl.UNSIGNED_BYTE,
-		gl.Ptr(r.Buffer.Pix))
+	backend().UploadSubImage(texture, r.Buffer, image.Point{})
 }
 
 func (r *BitmapRenderer) Clone() Renderer {
@@ -176,9 +180,9 @@ func NewBitmapRenderer(rgba *image.RGBA) (*BitmapRenderer, error) {
 		1.0, 1.0, 0.0, 1.0, 0.0,
 	}
 
-	texture := &GLTexture{
-		ID:   TextureFromRGBA(rgba, gl.TEXTURE0),
-		Size: [2]uint32{uint32(rgba.Rect.Size().X), uint32(rgba.Rect.Size().Y)},
+	texture, err := backend().CreateTexture(rgba)
+	if err != nil {
+		return nil, err
 	}
 
 	meshRenderer, err := CreateMeshRenderer(
@@ -203,12 +207,43 @@ func NewBitmapRenderer(rgba *image.RGBA) (*BitmapRenderer, error) {
 // TextRenderer
 //
 
+// TextRenderer : Draws text in a FontFace, broken into lines and placed
+// around the model origin according to Layout.  Characters are spaced by
+// their advance and the font's kerning.  Glyphs and Size are calculated
+// from Text and Layout by InitTransforms, and cleared by SetText and
+// SetLayout.  Uniforms are passed to each glyph, and must include
+// UniformColor.
 type TextRenderer struct {
-	MeshRenderers map[byte]Renderer
-	CharWidths    map[byte]float32
-	CharSpacer    float32
-	Text          []byte
-	Transforms    []mgl32.Mat4
+	Face     *FontFace
+	Uniforms map[int]interface{}
+	Text     []rune
+	Layout   TextLayout
+	Glyphs   []PlacedGlyph
+	Size     mgl32.Vec2
+}
+
+// NewTextRenderer : Creates a renderer drawing the text in the face with
+// the color, as a single line centered horizontally on the origin
+func NewTextRenderer(face *FontFace, text string, color mgl32.Vec4) *TextRenderer {
+	return &TextRenderer{
+		Face: face,
+		Uniforms: map[int]interface{}{
+			UniformColor: color,
+		},
+		Text: []rune(text),
+	}
+}
+
+// SetText : Replaces the text drawn by the renderer
+func (r *TextRenderer) SetText(text string) {
+	r.Text = []rune(text)
+	r.Glyphs = nil
+}
+
+// SetLayout : Replaces the layout of the text drawn by the renderer
+func (r *TextRenderer) SetLayout(layout TextLayout) {
+	r.Layout = layout
+	r.Glyphs = nil
 }
 
 func (r *TextRenderer) Render(model mgl32.Mat4) {
@@ -216,42 +251,60 @@ func (r *TextRenderer) Render(model mgl32.Mat4) {
 }
 
 func (r *TextRenderer) RenderAt(model mgl32.Mat4, custom map[int]interface{}) {
-	if len(r.Transforms) != len(r.Text) {
+	if r.Glyphs == nil {
 		r.InitTransforms()
 	}
 
-	for i := 0; i < len(r.Text); i++ {
-		renderer, ok := r.MeshRenderers[r.Text[i]]
-
-		if ok {
-			renderer.RenderAt(
-				model.Mul4(r.Transforms[i]),
-				map[int]interface{}{})
-		}
+	uniforms := make(map[int]interface{}, len(r.Uniforms)+len(custom))
+	for location, value := range r.Uniforms {
+		uniforms[location] = value
+	}
+	for location, value := range custom {
+		uniforms[location] = value
 	}
-}
 
-func (r *TextRenderer) InitTransforms() {
-	var translate float32
+	var spanUniforms map[int]interface{}
+	for _, placed := range r.Glyphs {
+		if placed.Glyph.Renderer == nil {
+			continue
+		}
 
-	if len(r.Text) == 0 {
-		return
-	}
+		glyphUniforms := uniforms
+		if placed.Color != nil {
+			if spanUniforms == nil {
+				spanUniforms = make(map[int]interface{}, len(uniforms))
+				for location, value := range uniforms {
+					spanUniforms[location] = value
+				}
+			}
+			spanUniforms[UniformColor] = spanColor(*placed.Color, uniforms[UniformColor])
+			glyphUniforms = spanUniforms
+		}
 
-	r.Transforms = make([]mgl32.Mat4, len(r.Text))
-	for count := 0; count < len(r.Text); count++ {
-		width, ok := r.CharWidths[r.Text[count]]
-		if ok {
-			r.Transforms[count] = mgl32.Translate3D(
-				translate+(width/2), 0, 0)
-			translate += width + r.CharSpacer
+		transform := model.Mul4(placed.Transform)
+		placed.Glyph.Renderer.RenderAt(transform, glyphUniforms)
+		if placed.Embolden > 0 {
+			placed.Glyph.Renderer.RenderAt(
+				transform.Mul4(mgl32.Translate3D(placed.Embolden, 0, 0)),
+				glyphUniforms)
 		}
 	}
+}
 
-	for count := 0; count < len(r.Text); count++ {
-		r.Transforms[count] = mgl32.Translate3D(-translate/2, 0, 0).
-			Mul4(r.Transforms[count])
+// spanColor : Returns the color of a span with its alpha multiplied by
+// the alpha of the text color
+func spanColor(color mgl32.Vec4, textColor interface{}) mgl32.Vec4 {
+	if base, ok := textColor.(mgl32.Vec4); ok {
+		color[3] *= base[3]
 	}
+	return color
+}
+
+// InitTransforms : Lays out the text, calculating the glyphs drawn for it
+// and their pen positions relative to the model origin, and the size of
+// the block of text
+func (r *TextRenderer) InitTransforms() {
+	r.Glyphs, r.Size = LayoutText(r.Face, r.Text, r.Layout)
 }
 
 func (r *TextRenderer) DebugRender(model mgl32.Mat4) {
@@ -259,16 +312,14 @@ func (r *TextRenderer) DebugRender(model mgl32.Mat4) {
 }
 
 func (r *TextRenderer) DebugRenderAt(model mgl32.Mat4, custom map[int]interface{}) {
-	if len(r.Transforms) != len(r.Text) {
+	if r.Glyphs == nil {
 		r.InitTransforms()
 	}
 
-	for i := 0; i < len(r.Text); i++ {
-		renderer, ok := r.MeshRenderers[r.Text[i]]
-
-		if ok {
-			renderer.DebugRenderAt(
-				model.Mul4(r.Transforms[i]),
+	for _, placed := range r.Glyphs {
+		if placed.Glyph.Renderer != nil {
+			placed.Glyph.Renderer.DebugRenderAt(
+				model.Mul4(placed.Transform),
 				map[int]interface{}{})
 		}
 	}
@@ -276,10 +327,19 @@ func (r *TextRenderer) DebugRenderAt(model mgl32.Mat4, custom map[int]interface{
 
 func (r *TextRenderer) Animate(model mgl32.Mat4) {}
 
+// Clone : Clones a TextRenderer.  The face is shared and the uniform
+// values are shallow copied.
 func (r *TextRenderer) Clone() Renderer {
+	uniforms := make(map[int]interface{})
+	for k, v := range r.Uniforms {
+		uniforms[k] = v
+	}
+
 	return &TextRenderer{
-		MeshRenderers: r.MeshRenderers,
-		CharSpacer:    r.CharSpacer,
+		Face:     r.Face,
+		Uniforms: uniforms,
+		Text:     append([]rune(nil), r.Text...),
+		Layout:   r.Layout,
 	}
 }
 
@@ -384,51 +444,20 @@ func (r *MeshRenderer) Render(model mgl32.Mat4) {
 }
 
 func (r *MeshRenderer) RenderAt(model mgl32.Mat4, custom map[int]interface{}) {
-	gl.UseProgram(r.Shader.Program)
-	gl.UniformMatrix4fv(r.Shader.Model, 1, false, &model[0])
-	gl.UniformMatrix4fv(r.Shader.Projection, 1, false, &glState.Projection[0])
-
-	r.bindCustomUniforms(r.Shader, custom)
-
-	gl.BindVertexArray(r.Mesh)
-
-	gl.DrawArrays(gl.TRIANGLES, 0, r.VertexCount)
+	backend().BindUniforms(r.Shader, model, glState.Projection, r.mergeUniforms(custom))
+	backend().Draw(r.Shader, r.Mesh, r.VertexCount)
 }
 
-// Binds statically defined and custom uniforms.  Custom uniforms take
-// precedence over statically defined uniforms.
-func (r *MeshRenderer) bindCustomUniforms(shader *GLShader, custom map[int]interface{}) {
-	glState.NextTextureUnit = 0
-
+// Returns the statically defined uniforms overridden by the custom uniforms.
+func (r *MeshRenderer) mergeUniforms(custom map[int]interface{}) map[int]interface{} {
+	result := make(map[int]interface{}, len(r.Uniforms)+len(custom))
 	for location, value := range r.Uniforms {
-		if _, exists := custom[location]; !exists {
-			r.bindCustomUniform(shader, location, value)
-		}
+		result[location] = value
 	}
-
 	for location, value := range custom {
-		r.bindCustomUniform(shader, location, value)
-	}
-}
-
-func (r *MeshRenderer) bindCustomUniform(
-	shader *GLShader, location int, value interface{},
-) {
-	switch tValue := value.(type) {
-	case *GLTexture:
-		gl.ActiveTexture(gl.TEXTURE0 + uint32(glState.NextTextureUnit))
-		gl.Uniform1i(shader.Uniforms[location], glState.NextTextureUnit)
-		gl.BindTexture(gl.TEXTURE_2D, tValue.ID)
-		glState.NextTextureUnit++
-	case int32:
-		gl.Uniform1i(shader.Uniforms[location], tValue)
-	case float32:
-		gl.Uniform1f(shader.Uniforms[location], tValue)
-	case mgl32.Vec4:
-		gl.Uniform4fv(shader.Uniforms[location], 1, &tValue[0])
-	default:
-		panic(fmt.Sprintf("Unhandled uniform(%v) value type: %t\n", location, value))
+		result[location] = value
 	}
+	return result
 }
 
 func (r *MeshRenderer) DebugRender(model mgl32.Mat4) {
@@ -440,7 +469,7 @@ func (r *MeshRenderer) DebugRenderAt(model mgl32.Mat4, custom map[int]interface{
 	log.Trace.Printf("MeshRenderer: Mesh vertices:\n%v\n", r.MeshVertices)
 
 	rendered := []mgl32.Vec4{}
-	for i := 0; i < len(r.MeshVertices); i += GlMeshStride {
+	for i := 0; i < len(r.MeshVertices); i += r.Shader.VertexStride() {
 		rv := mgl32.Vec4{
 			r.MeshVertices[i],
 			r.MeshVertices[i+1],
@@ -491,51 +520,22 @@ func CreateMeshRendererImpl(
 		return nil, err
 	}
 
-	gl.UseProgram(shader.Program)
-	gl.BindFragDataLocation(shader.Program, 0, fragLocOutputColor)
-
-	shader.Projection = gl.GetUniformLocation(shader.Program, shaderUniformLocProjection)
-	shader.Model = gl.GetUniformLocation(shader.Program, shaderUniformLocModel)
-
 	for _, uniform := range uniforms {
-		shader.Uniforms[uniform] = gl.GetUniformLocation(shader.Program, shaderUniforms[uniform])
+		if _, ok := shader.Uniforms[uniform]; !ok {
+			return nil, fmt.Errorf("unknown uniform: %v", uniform)
+		}
 	}
 
-	mesh := createMeshBuffer(shader.Program, meshVertices)
+	mesh, err := backend().CreateMeshBuffer(shader, meshVertices)
+	if err != nil {
+		return nil, err
+	}
 
 	return &MeshRenderer{
 		Shader:       shader,
 		Mesh:         mesh,
 		Uniforms:     uniformValues,
 		MeshVertices: meshVertices,
-		VertexCount:  int32(len(meshVertices) / GlMeshStride),
+		VertexCount:  int32(len(meshVertices) / shader.VertexStride()),
 	}, nil
 }
-
-func createMeshBuffer(shader uint32, vertices []float32) uint32 {
-	// Configure the vertex data
-	var vao uint32
-	gl.GenVertexArrays(1, &vao)
-	gl.BindVertexArray(vao)
-
-	var vbo uint32
-	gl.GenBuffers(1, &vbo)
-	gl.BindBuffer(gl.ARRAY_BUFFER, vbo)
-	gl.BufferData(
-		gl.ARRAY_BUFFER,
-		len(vertices)*4,
-		gl.Ptr(vertices),
-		gl.STATIC_DRAW)
-
-	vertAttrib := uint32(gl.GetAttribLocation(shader, attribLocVertex))
-	gl.EnableVertexAttribArray(vertAttrib)
-	gl.VertexAttribPointer(vertAttrib, 3, gl.FLOAT, false, glMeshStrideBytes,
-		gl.PtrOffset(0))
-
-	texCoordAttrib := uint32(gl.GetAttribLocation(shader, attribLocVertexTexCoord))
-	gl.EnableVertexAttribArray(texCoordAttrib)
-	gl.VertexAttribPointer(texCoordAttrib, 2, gl.FLOAT, false, glMeshStrideBytes,
-		gl.PtrOffset(3*4))
-
-	return vao
-}
@@ -0,0 +1,58 @@
+package render
+
+import (
+	"image/color"
+	"testing"
+)
+
+var gradient = []float64{
+	0.0, 0, 0, 0,
+	0.5, 200, 100, 0,
+	1.0, 200, 100, 255,
+}
+
+var gradientColorTests = []struct {
+	name     string
+	q        float64
+	expected color.RGBA
+}{
+	{"first stop", 0, color.RGBA{0, 0, 0, 255}},
+	{"before first stop", -1, color.RGBA{0, 0, 0, 255}},
+	{"between stops", 0.25, color.RGBA{100, 50, 0, 255}},
+	{"second stop", 0.5, color.RGBA{200, 100, 0, 255}},
+	{"second segment", 0.75, color.RGBA{200, 100, 128, 255}},
+	{"after last stop", 2, color.RGBA{200, 100, 255, 255}},
+}
+
+// TestGradientColor : Test that colors are interpolated between the stops
+// either side of the offset
+func TestGradientColor(t *testing.T) {
+	for _, tc := range gradientColorTests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := GradientColor(gradient, tc.q)
+			if actual != tc.expected {
+				t.Errorf("Color was (%v) should be (%v)", actual, tc.expected)
+			}
+		})
+	}
+}
+
+// TestColors : Test that colors are evenly spaced along the gradient
+func TestColors(t *testing.T) {
+	actual := Colors(gradient, 4)
+	expected := []color.RGBA{
+		{0, 0, 0, 255},
+		{100, 50, 0, 255},
+		{200, 100, 0, 255},
+		{200, 100, 128, 255},
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Colors was (%v) should be (%v)", actual, expected)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Color %v was (%v) should be (%v)", i, actual[i], expected[i])
+		}
+	}
+}
@@ -1,21 +1,27 @@
 package render
 
 import (
-	"fmt"
-	"strings"
-
-	"github.com/go-gl/gl/v4.1-core/gl"
-
 	// Bring in png so we support this file format
 	_ "image/png"
 )
 
-// GLShader : Stores core info for a GL shader
+// GLShader : Stores core info for a GL shader.  Stride is the number of
+// floats per mesh vertex expected by the vertex shader.
 type GLShader struct {
 	Program    uint32
 	Projection int32
 	Model      int32
 	Uniforms   map[int]int32
+	Stride     int
+}
+
+// VertexStride : Returns the number of floats per mesh vertex.  This is
+// GlMeshStride for a nil shader or one without per-vertex colors.
+func (s *GLShader) VertexStride() int {
+	if s == nil || s.Stride == 0 {
+		return GlMeshStride
+	}
+	return s.Stride
 }
 
 const float32SizeBytes = 4
@@ -30,25 +36,22 @@ var (
 	UniformTexture    = 3
 	UniformAlpha      = 4
 	UniformColor      = 5
+	UniformTexRect    = 6
 )
 
-var (
-	shaderUniformLocProjection = gl.Str("projection\x00")
-	shaderUniformLocModel      = gl.Str("model\x00")
-)
-
-var shaderUniforms = map[int]*uint8{
-	UniformTexture: gl.Str("tex\x00"),
-	UniformAlpha:   gl.Str("alpha\x00"),
-	UniformColor:   gl.Str("color\x00"),
+// shaderUniforms : The names of the uniform variables in the shader source
+var shaderUniforms = map[int]string{
+	UniformTexture: "tex",
+	UniformAlpha:   "alpha",
+	UniformColor:   "color",
+	UniformTexRect: "texRect",
 }
 
-var fragLocOutputColor = gl.Str("outputColor\x00")
-
-var (
-	attribLocVertex         = gl.Str("vert\x00")
-	attribLocVertexTexCoord = gl.Str("vertTexCoord\x00")
-)
+// vertexStrides : The mesh vertex layout of vertex shaders which do not
+// use GlMeshStride
+var vertexStrides = map[string]int{
+	"COLOR_VERTEX_SHADER": GlColorMeshStride,
+}
 
 var shaders = map[string]string{
 	"FULLSCREEN_VERTEX_SHADER": `
@@ -77,6 +80,40 @@ void main() {
     fragTexCoord = vertTexCoord;
     gl_Position = projection * model * vec4(vert, 1);
 }
+` + "\x00",
+	"SPRITE_VERTEX_SHADER": `
+#version 330
+
+uniform mat4 projection;
+uniform mat4 model;
+uniform vec4 texRect;
+
+in vec3 vert;
+in vec2 vertTexCoord;
+out vec2 fragTexCoord;
+
+void main() {
+    fragTexCoord = texRect.xy + vertTexCoord * texRect.zw;
+    gl_Position = projection * model * vec4(vert, 1);
+}
+` + "\x00",
+	"COLOR_VERTEX_SHADER": `
+#version 330
+
+uniform mat4 projection;
+uniform mat4 model;
+
+in vec3 vert;
+in vec2 vertTexCoord;
+in vec4 vertColor;
+out vec2 fragTexCoord;
+out vec4 fragColor;
+
+void main() {
+    fragTexCoord = vertTexCoord;
+    fragColor = vertColor;
+    gl_Position = projection * model * vec4(vert, 1);
+}
 ` + "\x00",
 
 	"TEXTURE_FRAGMENT_SHADER": `
@@ -106,6 +143,23 @@ void main() {
     texColor = texture(tex, fragTexCoord);
     outputColor = vec4(texColor.rgb, texColor.a * alpha);
 }
+` + "\x00",
+
+	"SPRITE_FRAGMENT_SHADER": `
+#version 330
+
+uniform sampler2D tex;
+uniform vec4 color;
+uniform float alpha;
+
+vec4 texColor;
+in vec2 fragTexCoord;
+out vec4 outputColor;
+
+void main() {
+    texColor = texture(tex, fragTexCoord) * color;
+    outputColor = vec4(texColor.rgb, texColor.a * alpha);
+}
 ` + "\x00",
 
 	"COLOR_FRAGMENT_SHADER": `
@@ -118,6 +172,33 @@ out vec4 outputColor;
 void main() {
     outputColor = color;
 }
+` + "\x00",
+
+	"TEXT_FRAGMENT_SHADER": `
+#version 330
+
+uniform sampler2D tex;
+uniform vec4 color;
+
+in vec2 fragTexCoord;
+out vec4 outputColor;
+
+void main() {
+    outputColor = vec4(color.rgb, color.a * texture(tex, fragTexCoord).a);
+}
+` + "\x00",
+
+	"VERTEX_COLOR_FRAGMENT_SHADER": `
+#version 330
+
+uniform float alpha;
+
+in vec4 fragColor;
+out vec4 outputColor;
+
+void main() {
+    outputColor = vec4(fragColor.rgb, fragColor.a * alpha);
+}
 ` + "\x00",
 }
 
@@ -128,73 +209,13 @@ func CreateShaderProgram(vertexShader string, fragmentShader string) (*GLShader,
 	program, programExists := glState.Shaders[programKey]
 	if !programExists {
 		var err error
-		program, err = loadProgram(shaders[vertexShader], shaders[fragmentShader])
+		program, err = backend().CompileProgram(vertexShader, fragmentShader)
 		if err != nil {
 			return nil, err
 		}
+		program.Stride = vertexStrides[vertexShader]
 		glState.Shaders[programKey] = program
 	}
 
 	return program, nil
 }
-
-func loadProgram(vertexShaderSource, fragmentShaderSource string) (*GLShader, error) {
-	vertexShader, err := compileShader(vertexShaderSource, gl.VERTEX_SHADER)
-	if err != nil {
-		return nil, err
-	}
-
-	fragmentShader, err := compileShader(fragmentShaderSource, gl.FRAGMENT_SHADER)
-	if err != nil {
-		return nil, err
-	}
-
-	program := gl.CreateProgram()
-
-	gl.AttachShader(program, vertexShader)
-	gl.AttachShader(program, fragmentShader)
-	gl.LinkProgram(program)
-
-	var status int32
-	gl.GetProgramiv(program, gl.LINK_STATUS, &status)
-	if status == gl.FALSE {
-		var logLength int32
-		gl.GetProgramiv(program, gl.INFO_LOG_LENGTH, &logLength)
-
-		log := strings.Repeat("\x00", int(logLength+1))
-		gl.GetProgramInfoLog(program, logLength, nil, gl.Str(log))
-
-		return nil, fmt.Errorf("failed to link program: %v", log)
-	}
-
-	gl.DeleteShader(vertexShader)
-	gl.DeleteShader(fragmentShader)
-
-	return &GLShader{
-		Program:  program,
-		Uniforms: map[int]int32{},
-	}, nil
-}
-
-func compileShader(source string, shaderType uint32) (uint32, error) {
-	shader := gl.CreateShader(shaderType)
-
-	csources, free := gl.Strs(source)
-	gl.ShaderSource(shader, 1, csources, nil)
-	free()
-	gl.CompileShader(shader)
-
-	var status int32
-	gl.GetShaderiv(shader, gl.COMPILE_STATUS, &status)
-	if status == gl.FALSE {
-		var logLength int32
-		gl.GetShaderiv(shader, gl.INFO_LOG_LENGTH, &logLength)
-
-		log := strings.Repeat("\x00", int(logLength+1))
-		gl.GetShaderInfoLog(shader, logLength, nil, gl.Str(log))
-
-		return 0, fmt.Errorf("failed to compile %v: %v", log, source)
-	}
-
-	return shader, nil
-}
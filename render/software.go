@@ -0,0 +1,451 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// SoftwareBackend : Rasterizes meshes into an image without a GPU or
+// window.  Shader programs are emulated in Go, so only the shaders defined
+// in this package are supported.
+type SoftwareBackend struct {
+	Target     *image.RGBA
+	ClearColor color.RGBA
+	Textures   map[uint32]*image.RGBA
+	Programs   map[uint32][2]string
+	Meshes     map[uint32][]float32
+	NextID     uint32
+
+	transform mgl32.Mat4
+	texRect   mgl32.Vec4
+	shade     fragmentShader
+}
+
+// fragmentShader : Computes the colour of a fragment from its interpolated
+// texture co-ordinate and vertex color.  Vertex color is white for meshes
+// without per-vertex colors.
+type fragmentShader func(texCoord mgl32.Vec2, color mgl32.Vec4) mgl32.Vec4
+
+// NewSoftwareBackend : Creates a software backend which renders into a
+// width x height image
+func NewSoftwareBackend(width int, height int) *SoftwareBackend {
+	return &SoftwareBackend{
+		Target:     image.NewRGBA(image.Rect(0, 0, width, height)),
+		ClearColor: color.RGBA{0, 0, 0, 255},
+		Textures:   map[uint32]*image.RGBA{},
+		Programs:   map[uint32][2]string{},
+		Meshes:     map[uint32][]float32{},
+		NextID:     1,
+	}
+}
+
+// InitSoftware : Initialises the software rasterizer as the rendering
+// backend.  All subsequent rendering is drawn into the returned image.
+func InitSoftware(width int, height int) (*image.RGBA, error) {
+	backend := NewSoftwareBackend(width, height)
+	if err := Init(backend); err != nil {
+		return nil, err
+	}
+
+	return backend.Target, nil
+}
+
+func (s *SoftwareBackend) Init() error {
+	return nil
+}
+
+func (s *SoftwareBackend) Clear() {
+	c := s.ClearColor
+	for i := 0; i < len(s.Target.Pix); i += 4 {
+		s.Target.Pix[i] = c.R
+		s.Target.Pix[i+1] = c.G
+		s.Target.Pix[i+2] = c.B
+		s.Target.Pix[i+3] = c.A
+	}
+}
+
+func (s *SoftwareBackend) CompileProgram(vertexShader string, fragmentShader string) (*GLShader, error) {
+	if _, ok := shaders[vertexShader]; !ok {
+		return nil, fmt.Errorf("unknown vertex shader: %v", vertexShader)
+	}
+	if _, ok := shaders[fragmentShader]; !ok {
+		return nil, fmt.Errorf("unknown fragment shader: %v", fragmentShader)
+	}
+	if _, ok := softwareFragmentShaders[fragmentShader]; !ok {
+		return nil, fmt.Errorf("fragment shader has no software emulation: %v", fragmentShader)
+	}
+
+	shader := &GLShader{
+		Program:  s.nextID(),
+		Uniforms: map[int]int32{},
+	}
+	for uniform := range shaderUniforms {
+		shader.Uniforms[uniform] = int32(uniform)
+	}
+	s.Programs[shader.Program] = [2]string{vertexShader, fragmentShader}
+
+	return shader, nil
+}
+
+func (s *SoftwareBackend) CreateMeshBuffer(shader *GLShader, vertices []float32) (uint32, error) {
+	mesh := s.nextID()
+	s.Meshes[mesh] = append([]float32(nil), vertices...)
+	return mesh, nil
+}
+
+func (s *SoftwareBackend) CreateTexture(rgba *image.RGBA) (*GLTexture, error) {
+	id := s.nextID()
+	s.Textures[id] = copyRGBA(rgba)
+
+	return &GLTexture{
+		ID:   id,
+		Size: [2]uint32{uint32(rgba.Rect.Size().X), uint32(rgba.Rect.Size().Y)},
+	}, nil
+}
+
+func (s *SoftwareBackend) UploadSubImage(texture *GLTexture, rgba *image.RGBA, offset image.Point) {
+	dst, ok := s.Textures[texture.ID]
+	if !ok {
+		return
+	}
+
+	size := rgba.Rect.Size()
+	for y := 0; y < size.Y; y++ {
+		if offset.Y+y < 0 || offset.Y+y >= dst.Rect.Size().Y {
+			continue
+		}
+		for x := 0; x < size.X; x++ {
+			if offset.X+x < 0 || offset.X+x >= dst.Rect.Size().X {
+				continue
+			}
+			si := rgba.PixOffset(rgba.Rect.Min.X+x, rgba.Rect.Min.Y+y)
+			di := dst.PixOffset(offset.X+x, offset.Y+y)
+			copy(dst.Pix[di:di+4], rgba.Pix[si:si+4])
+		}
+	}
+}
+
+// BindUniforms : Selects the software equivalent of the shader program
+// with the supplied uniforms for subsequent calls to Draw.
+func (s *SoftwareBackend) BindUniforms(
+	shader *GLShader,
+	model mgl32.Mat4,
+	projection mgl32.Mat4,
+	uniforms map[int]interface{},
+) {
+	program := s.Programs[shader.Program]
+
+	s.transform = mgl32.Ident4()
+	if program[0] != "FULLSCREEN_VERTEX_SHADER" {
+		s.transform = projection.Mul4(model)
+	}
+
+	s.texRect = mgl32.Vec4{0, 0, 1, 1}
+	if texRect, ok := uniforms[UniformTexRect].(mgl32.Vec4); ok && program[0] == "SPRITE_VERTEX_SHADER" {
+		s.texRect = texRect
+	}
+
+	s.shade = s.fragmentShader(program[1], uniforms)
+}
+
+func (s *SoftwareBackend) Draw(shader *GLShader, mesh uint32, vertexCount int32) {
+	if s.shade == nil {
+		return
+	}
+
+	stride := shader.VertexStride()
+	vertices := s.Meshes[mesh]
+	if int(vertexCount)*stride < len(vertices) {
+		vertices = vertices[:int(vertexCount)*stride]
+	}
+
+	for i := 0; i+3*stride <= len(vertices); i += 3 * stride {
+		s.drawTriangle(
+			s.transform,
+			vertices[i:i+stride],
+			vertices[i+stride:i+2*stride],
+			vertices[i+2*stride:i+3*stride],
+			s.shade)
+	}
+}
+
+func (s *SoftwareBackend) nextID() uint32 {
+	id := s.NextID
+	s.NextID++
+	return id
+}
+
+// softwareFragmentShaders : Emulations of the fragment shaders, by name,
+// given the bound texture, color and alpha uniforms
+var softwareFragmentShaders = map[string]func(tex *image.RGBA, col mgl32.Vec4, alpha float32) fragmentShader{
+	"TEXTURE_FRAGMENT_SHADER": func(tex *image.RGBA, col mgl32.Vec4, alpha float32) fragmentShader {
+		return func(tc mgl32.Vec2, vc mgl32.Vec4) mgl32.Vec4 {
+			return sampleTexture(tex, tc[0], tc[1])
+		}
+	},
+	"ALPHA_FRAGMENT_SHADER": func(tex *image.RGBA, col mgl32.Vec4, alpha float32) fragmentShader {
+		return func(tc mgl32.Vec2, vc mgl32.Vec4) mgl32.Vec4 {
+			c := sampleTexture(tex, tc[0], tc[1])
+			return mgl32.Vec4{c[0], c[1], c[2], c[3] * alpha}
+		}
+	},
+	"SPRITE_FRAGMENT_SHADER": func(tex *image.RGBA, col mgl32.Vec4, alpha float32) fragmentShader {
+		return func(tc mgl32.Vec2, vc mgl32.Vec4) mgl32.Vec4 {
+			c := sampleTexture(tex, tc[0], tc[1])
+			return mgl32.Vec4{c[0] * col[0], c[1] * col[1], c[2] * col[2], c[3] * col[3] * alpha}
+		}
+	},
+	"COLOR_FRAGMENT_SHADER": func(tex *image.RGBA, col mgl32.Vec4, alpha float32) fragmentShader {
+		return func(tc mgl32.Vec2, vc mgl32.Vec4) mgl32.Vec4 {
+			return col
+		}
+	},
+	"TEXT_FRAGMENT_SHADER": func(tex *image.RGBA, col mgl32.Vec4, alpha float32) fragmentShader {
+		return func(tc mgl32.Vec2, vc mgl32.Vec4) mgl32.Vec4 {
+			c := sampleTexture(tex, tc[0], tc[1])
+			return mgl32.Vec4{col[0], col[1], col[2], col[3] * c[3]}
+		}
+	},
+	"VERTEX_COLOR_FRAGMENT_SHADER": func(tex *image.RGBA, col mgl32.Vec4, alpha float32) fragmentShader {
+		return func(tc mgl32.Vec2, vc mgl32.Vec4) mgl32.Vec4 {
+			return mgl32.Vec4{vc[0], vc[1], vc[2], vc[3] * alpha}
+		}
+	},
+}
+
+// fragmentShader : Returns the emulation of the named fragment shader with
+// the supplied uniforms.  Panics if the shader has no emulation, which
+// CompileProgram does not allow.
+func (s *SoftwareBackend) fragmentShader(
+	name string, uniforms map[int]interface{},
+) fragmentShader {
+	emulation, ok := softwareFragmentShaders[name]
+	if !ok {
+		panic(fmt.Sprintf("Failed to bind software shader: no emulation of fragment shader %v\n", name))
+	}
+
+	var tex *image.RGBA
+	if texture, ok := uniforms[UniformTexture].(*GLTexture); ok {
+		tex = s.Textures[texture.ID]
+	}
+	alpha := uniformFloat(uniforms[UniformAlpha], 1.0)
+	col, _ := uniforms[UniformColor].(mgl32.Vec4)
+
+	return emulation(tex, col, alpha)
+}
+
+// drawTriangle : Rasterizes a single triangle sampling at pixel centres.
+// Edges shared between triangles are only filled once (top-left rule)
+// so that translucent meshes blend correctly.
+func (s *SoftwareBackend) drawTriangle(
+	transform mgl32.Mat4,
+	a []float32,
+	b []float32,
+	c []float32,
+	shade fragmentShader,
+) {
+	p0 := s.toScreen(transform, a)
+	p1 := s.toScreen(transform, b)
+	p2 := s.toScreen(transform, c)
+	t0 := s.texCoord(a)
+	t1 := s.texCoord(b)
+	t2 := s.texCoord(c)
+	c0 := vertexColor(a)
+	c1 := vertexColor(b)
+	c2 := vertexColor(c)
+
+	area := edgeFunction(p0, p1, p2)
+	if area == 0 {
+		return
+	}
+	if area < 0 {
+		p1, p2 = p2, p1
+		t1, t2 = t2, t1
+		c1, c2 = c2, c1
+		area = -area
+	}
+
+	bounds := s.Target.Rect
+	minX := clampInt(int(math.Floor(float64(min3(p0[0], p1[0], p2[0])))), bounds.Min.X, bounds.Max.X)
+	maxX := clampInt(int(math.Ceil(float64(max3(p0[0], p1[0], p2[0])))), bounds.Min.X, bounds.Max.X)
+	minY := clampInt(int(math.Floor(float64(min3(p0[1], p1[1], p2[1])))), bounds.Min.Y, bounds.Max.Y)
+	maxY := clampInt(int(math.Ceil(float64(max3(p0[1], p1[1], p2[1])))), bounds.Min.Y, bounds.Max.Y)
+
+	for y := minY; y < maxY; y++ {
+		for x := minX; x < maxX; x++ {
+			p := mgl32.Vec2{float32(x) + 0.5, float32(y) + 0.5}
+
+			w0 := edgeFunction(p1, p2, p)
+			w1 := edgeFunction(p2, p0, p)
+			w2 := edgeFunction(p0, p1, p)
+			if !insideEdge(w0, p1, p2) || !insideEdge(w1, p2, p0) || !insideEdge(w2, p0, p1) {
+				continue
+			}
+
+			tc := t0.Mul(w0 / area).Add(t1.Mul(w1 / area)).Add(t2.Mul(w2 / area))
+			vc := c0.Mul(w0 / area).Add(c1.Mul(w1 / area)).Add(c2.Mul(w2 / area))
+			s.blend(x, y, shade(tc, vc))
+		}
+	}
+}
+
+// texCoord : Returns the texture co-ordinate of a mesh vertex mapped into
+// the texture rectangle of the bound program
+func (s *SoftwareBackend) texCoord(vertex []float32) mgl32.Vec2 {
+	return mgl32.Vec2{
+		s.texRect[0] + vertex[3]*s.texRect[2],
+		s.texRect[1] + vertex[4]*s.texRect[3],
+	}
+}
+
+// vertexColor : Returns the color of a mesh vertex, or white if the vertex
+// has no color
+func vertexColor(vertex []float32) mgl32.Vec4 {
+	if len(vertex) < GlColorMeshStride {
+		return mgl32.Vec4{1, 1, 1, 1}
+	}
+	return mgl32.Vec4{vertex[5], vertex[6], vertex[7], vertex[8]}
+}
+
+// toScreen : Transforms a mesh vertex to pixel co-ordinates in the target.
+// Normalised device co-ordinates have Y up whereas image rows run down.
+func (s *SoftwareBackend) toScreen(transform mgl32.Mat4, vertex []float32) mgl32.Vec2 {
+	clip := transform.Mul4x1(mgl32.Vec4{vertex[0], vertex[1], vertex[2], 1.0})
+	size := s.Target.Rect.Size()
+
+	return mgl32.Vec2{
+		(clip[0]/clip[3] + 1.0) * 0.5 * float32(size.X),
+		(1.0 - clip[1]/clip[3]) * 0.5 * float32(size.Y),
+	}
+}
+
+// blend : Blends the colour into the target pixel equivalent to
+// glBlendFunc(GL_SRC_ALPHA, GL_ONE_MINUS_SRC_ALPHA).  Alpha is accumulated
+// so an opaque target remains opaque.
+func (s *SoftwareBackend) blend(x int, y int, c mgl32.Vec4) {
+	a := clampFloat(c[3])
+	i := s.Target.PixOffset(x, y)
+	pix := s.Target.Pix[i : i+4 : i+4]
+
+	for j := 0; j < 3; j++ {
+		dst := float32(pix[j]) / 255.0
+		pix[j] = toByte(clampFloat(c[j])*a + dst*(1.0-a))
+	}
+	dstAlpha := float32(pix[3]) / 255.0
+	pix[3] = toByte(a + dstAlpha*(1.0-a))
+}
+
+// sampleTexture : Bilinearly samples the texture clamping to the edges,
+// equivalent to GL_LINEAR with GL_CLAMP_TO_EDGE.
+func sampleTexture(tex *image.RGBA, u float32, v float32) mgl32.Vec4 {
+	if tex == nil {
+		return mgl32.Vec4{0, 0, 0, 1}
+	}
+
+	size := tex.Rect.Size()
+	if size.X == 0 || size.Y == 0 {
+		return mgl32.Vec4{0, 0, 0, 1}
+	}
+
+	x := u*float32(size.X) - 0.5
+	y := v*float32(size.Y) - 0.5
+	x0 := int(math.Floor(float64(x)))
+	y0 := int(math.Floor(float64(y)))
+	fx := x - float32(x0)
+	fy := y - float32(y0)
+
+	c00 := texel(tex, x0, y0)
+	c10 := texel(tex, x0+1, y0)
+	c01 := texel(tex, x0, y0+1)
+	c11 := texel(tex, x0+1, y0+1)
+
+	top := c00.Mul(1 - fx).Add(c10.Mul(fx))
+	bottom := c01.Mul(1 - fx).Add(c11.Mul(fx))
+
+	return top.Mul(1 - fy).Add(bottom.Mul(fy))
+}
+
+func texel(tex *image.RGBA, x int, y int) mgl32.Vec4 {
+	size := tex.Rect.Size()
+	x = clampInt(x, 0, size.X-1) + tex.Rect.Min.X
+	y = clampInt(y, 0, size.Y-1) + tex.Rect.Min.Y
+	i := tex.PixOffset(x, y)
+
+	return mgl32.Vec4{
+		float32(tex.Pix[i]) / 255.0,
+		float32(tex.Pix[i+1]) / 255.0,
+		float32(tex.Pix[i+2]) / 255.0,
+		float32(tex.Pix[i+3]) / 255.0,
+	}
+}
+
+func edgeFunction(a mgl32.Vec2, b mgl32.Vec2, p mgl32.Vec2) float32 {
+	return (b[0]-a[0])*(p[1]-a[1]) - (b[1]-a[1])*(p[0]-a[0])
+}
+
+// insideEdge : Points exactly on an edge are only inside for one of the
+// two triangles sharing that edge, as each traverses it in opposite directions.
+func insideEdge(w float32, a mgl32.Vec2, b mgl32.Vec2) bool {
+	if w != 0 {
+		return w > 0
+	}
+	d := b.Sub(a)
+	return d[1] > 0 || (d[1] == 0 && d[0] < 0)
+}
+
+func uniformFloat(value interface{}, fallback float32) float32 {
+	switch tValue := value.(type) {
+	case float32:
+		return tValue
+	case float64:
+		return float32(tValue)
+	default:
+		return fallback
+	}
+}
+
+func copyRGBA(rgba *image.RGBA) *image.RGBA {
+	result := image.NewRGBA(image.Rect(0, 0, rgba.Rect.Size().X, rgba.Rect.Size().Y))
+	for y := 0; y < result.Rect.Size().Y; y++ {
+		src := rgba.PixOffset(rgba.Rect.Min.X, rgba.Rect.Min.Y+y)
+		dst := result.PixOffset(0, y)
+		copy(result.Pix[dst:dst+4*result.Rect.Size().X], rgba.Pix[src:])
+	}
+	return result
+}
+
+func toByte(f float32) uint8 {
+	return uint8(f*255.0 + 0.5)
+}
+
+func clampFloat(f float32) float32 {
+	if f < 0 {
+		return 0
+	}
+	if f > 1 {
+		return 1
+	}
+	return f
+}
+
+func clampInt(i int, lower int, upper int) int {
+	if i < lower {
+		return lower
+	}
+	if i > upper {
+		return upper
+	}
+	return i
+}
+
+func min3(a float32, b float32, c float32) float32 {
+	return float32(math.Min(float64(a), math.Min(float64(b), float64(c))))
+}
+
+func max3(a float32, b float32, c float32) float32 {
+	return float32(math.Max(float64(a), math.Max(float64(b), float64(c))))
+}
@@ -0,0 +1,174 @@
+package render
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo/log"
+)
+
+func TestMain(m *testing.M) {
+	log.InitLogger(io.Discard, os.Stdout, os.Stdout, os.Stderr)
+	code := m.Run()
+	os.Exit(code)
+}
+
+var softwareFillTests = []struct {
+	name     string
+	x, y     int
+	expected [4]uint8
+}{
+	{"inside", 10, 10, [4]uint8{255, 0, 0, 255}},
+	{"outside", 30, 30, [4]uint8{0, 0, 0, 255}},
+	{"top left corner", 5, 5, [4]uint8{255, 0, 0, 255}},
+	{"bottom right exclusive", 15, 15, [4]uint8{0, 0, 0, 255}},
+}
+
+// TestSoftwareFill : Test that a square mesh is rasterized into the
+// expected pixels with image rows running down from the top of the screen
+func TestSoftwareFill(t *testing.T) {
+	frame, err := InitSoftware(40, 40)
+	if err != nil {
+		t.Fatalf("InitSoftware failed: %v", err)
+	}
+	Set2DProjection(40, 40)
+
+	// Square from (5, 25) to (15, 35) in world space, which is
+	// pixels (5, 5) to (15, 15) in the image.
+	renderer, err := CreateMeshRenderer(
+		"ORTHO_VERTEX_SHADER",
+		"COLOR_FRAGMENT_SHADER",
+		[]int{UniformColor},
+		map[int]interface{}{
+			UniformColor: mgl32.Vec4{1.0, 0.0, 0.0, 1.0},
+		},
+		[]float32{
+			-5, -5, 0, 0, 1,
+			5, 5, 0, 1, 0,
+			-5, 5, 0, 0, 0,
+			-5, -5, 0, 0, 1,
+			5, -5, 0, 1, 1,
+			5, 5, 0, 1, 0,
+		})
+	if err != nil {
+		t.Fatalf("CreateMeshRenderer failed: %v", err)
+	}
+
+	ClearBackBuffer()
+	renderer.Render(mgl32.Translate3D(10, 30, 0))
+
+	for _, tc := range softwareFillTests {
+		t.Run(tc.name, func(t *testing.T) {
+			i := frame.PixOffset(tc.x, tc.y)
+			var actual [4]uint8
+			copy(actual[:], frame.Pix[i:i+4])
+			if actual != tc.expected {
+				t.Errorf("Pixel (%v, %v) was %v should be %v", tc.x, tc.y, actual, tc.expected)
+			}
+		})
+	}
+}
+
+// TestSoftwareBlend : Test that translucent colours are blended with the
+// existing frame and shared edges are only drawn once
+func TestSoftwareBlend(t *testing.T) {
+	frame, err := InitSoftware(4, 4)
+	if err != nil {
+		t.Fatalf("InitSoftware failed: %v", err)
+	}
+
+	renderer, err := CreateMeshRenderer(
+		"FULLSCREEN_VERTEX_SHADER",
+		"COLOR_FRAGMENT_SHADER",
+		[]int{UniformColor},
+		map[int]interface{}{
+			UniformColor: mgl32.Vec4{1.0, 1.0, 1.0, 0.5},
+		},
+		[]float32{
+			-1, -1, 0, 0, 1,
+			1, 1, 0, 1, 0,
+			-1, 1, 0, 0, 0,
+			-1, -1, 0, 0, 1,
+			1, -1, 0, 1, 1,
+			1, 1, 0, 1, 0,
+		})
+	if err != nil {
+		t.Fatalf("CreateMeshRenderer failed: %v", err)
+	}
+
+	ClearBackBuffer()
+	renderer.Render(mgl32.Ident4())
+
+	for i := 0; i < len(frame.Pix); i += 4 {
+		if frame.Pix[i] != 128 || frame.Pix[i+3] != 255 {
+			t.Fatalf("Pixel %v was %v should be half white and opaque", i/4, frame.Pix[i:i+4])
+		}
+	}
+}
+
+// TestSoftwareVertexColor : Test that vertex colors are interpolated across
+// a triangle and multiplied by alpha
+func TestSoftwareVertexColor(t *testing.T) {
+	frame, err := InitSoftware(40, 40)
+	if err != nil {
+		t.Fatalf("InitSoftware failed: %v", err)
+	}
+	Set2DProjection(40, 40)
+
+	// A horizontal band from red at x = 0 to blue at x = 40
+	renderer, err := CreateMeshRenderer(
+		"COLOR_VERTEX_SHADER",
+		"VERTEX_COLOR_FRAGMENT_SHADER",
+		[]int{UniformAlpha},
+		map[int]interface{}{
+			UniformAlpha: float32(1.0),
+		},
+		[]float32{
+			0, 0, 0, 0, 0, 1, 0, 0, 1,
+			40, 0, 0, 0, 0, 0, 0, 1, 1,
+			40, 40, 0, 0, 0, 0, 0, 1, 1,
+			0, 0, 0, 0, 0, 1, 0, 0, 1,
+			40, 40, 0, 0, 0, 0, 0, 1, 1,
+			0, 40, 0, 0, 0, 1, 0, 0, 1,
+		})
+	if err != nil {
+		t.Fatalf("CreateMeshRenderer failed: %v", err)
+	}
+	if renderer.VertexCount != 6 {
+		t.Errorf("VertexCount was (%v) should be (%v)", renderer.VertexCount, 6)
+	}
+
+	ClearBackBuffer()
+	renderer.Render(mgl32.Ident4())
+
+	i := frame.PixOffset(19, 20)
+	if r, b := frame.Pix[i], frame.Pix[i+2]; r < 124 || r > 132 || b < 124 || b > 132 {
+		t.Errorf("Middle pixel was %v should be half red and half blue", frame.Pix[i:i+4])
+	}
+
+	ClearBackBuffer()
+	renderer.RenderAt(mgl32.Ident4(), map[int]interface{}{UniformAlpha: float32(0.5)})
+
+	i = frame.PixOffset(0, 20)
+	if r := frame.Pix[i]; r < 124 || r > 128 {
+		t.Errorf("Left pixel red was (%v) should be (%v)", r, 126)
+	}
+}
+
+// TestSoftwareUnknownShader : Test that programs with a fragment shader
+// the software backend cannot emulate fail to compile
+func TestSoftwareUnknownShader(t *testing.T) {
+	backend := NewSoftwareBackend(8, 8)
+
+	shaders["TEST_FRAGMENT_SHADER"] = shaders["COLOR_FRAGMENT_SHADER"]
+	defer delete(shaders, "TEST_FRAGMENT_SHADER")
+
+	if _, err := backend.CompileProgram("ORTHO_VERTEX_SHADER", "TEST_FRAGMENT_SHADER"); err == nil {
+		t.Errorf("CompileProgram should fail for a fragment shader without an emulation")
+	}
+	if _, err := backend.CompileProgram("ORTHO_VERTEX_SHADER", "COLOR_FRAGMENT_SHADER"); err != nil {
+		t.Errorf("CompileProgram failed: %v", err)
+	}
+}
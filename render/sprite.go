@@ -0,0 +1,161 @@
+package render
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+/////////////////////////////////////////////////////////////
+// SpriteRenderer
+//
+
+// SpriteRenderer : Draws the Region of a texture, in texture pixels, as a
+// rectangle of the same size in world units centered on the model origin.
+// FlipX and FlipY mirror the region horizontally and vertically.  The
+// texture is tinted by UniformColor and faded by UniformAlpha.  Image is
+// the bounds within the texture of the image the sprite is taken from,
+// which is smaller than the texture if the image is packed into an atlas.
+type SpriteRenderer struct {
+	MeshRenderer *MeshRenderer
+	Region       image.Rectangle
+	Image        image.Rectangle
+	FlipX        bool
+	FlipY        bool
+}
+
+// spriteVertices : A unit square centered on the origin covering the
+// whole of the texture rectangle
+var spriteVertices = []float32{
+	// Bottom left
+	-0.5, -0.5, 0.0, 0.0, 1.0,
+	// Top right
+	0.5, 0.5, 0.0, 1.0, 0.0,
+	// Top left
+	-0.5, 0.5, 0.0, 0.0, 0.0,
+	// Bottom left
+	-0.5, -0.5, 0.0, 0.0, 1.0,
+	// Bottom right
+	0.5, -0.5, 0.0, 1.0, 1.0,
+	// Top right
+	0.5, 0.5, 0.0, 1.0, 0.0,
+}
+
+// NewSpriteRenderer : Creates a renderer drawing the region of the
+// texture, untinted and opaque.  The region is also used as the bounds of
+// the image.
+func NewSpriteRenderer(texture *GLTexture, region image.Rectangle) (*SpriteRenderer, error) {
+	if region.Empty() {
+		return nil, fmt.Errorf("empty sprite region: %v", region)
+	}
+
+	meshRenderer, err := CreateMeshRenderer(
+		"SPRITE_VERTEX_SHADER",
+		"SPRITE_FRAGMENT_SHADER",
+		[]int{UniformTexture, UniformColor, UniformAlpha, UniformTexRect},
+		map[int]interface{}{
+			UniformTexture: texture,
+			UniformColor:   mgl32.Vec4{1.0, 1.0, 1.0, 1.0},
+			UniformAlpha:   float32(1.0),
+		},
+		spriteVertices)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SpriteRenderer{
+		MeshRenderer: meshRenderer,
+		Region:       region,
+		Image:        region,
+	}, nil
+}
+
+// Texture : Returns the texture the sprite is drawn from
+func (r *SpriteRenderer) Texture() *GLTexture {
+	texture, _ := r.MeshRenderer.Uniforms[UniformTexture].(*GLTexture)
+	return texture
+}
+
+// Size : Returns the width and height of the sprite in world units
+func (r *SpriteRenderer) Size() mgl32.Vec2 {
+	size := r.Region.Size()
+	return mgl32.Vec2{float32(size.X), float32(size.Y)}
+}
+
+// TexRect : Returns the texture co-ordinates of the top left of the
+// region and the offset to the bottom right, with the offset negated
+// along flipped axes
+func (r *SpriteRenderer) TexRect() mgl32.Vec4 {
+	texture := r.Texture()
+	if texture == nil || texture.Size[0] == 0 || texture.Size[1] == 0 {
+		return mgl32.Vec4{0, 0, 1, 1}
+	}
+
+	width := float32(texture.Size[0])
+	height := float32(texture.Size[1])
+	rect := mgl32.Vec4{
+		float32(r.Region.Min.X) / width,
+		float32(r.Region.Min.Y) / height,
+		float32(r.Region.Dx()) / width,
+		float32(r.Region.Dy()) / height,
+	}
+
+	if r.FlipX {
+		rect[0] += rect[2]
+		rect[2] = -rect[2]
+	}
+	if r.FlipY {
+		rect[1] += rect[3]
+		rect[3] = -rect[3]
+	}
+	return rect
+}
+
+func (r *SpriteRenderer) Render(model mgl32.Mat4) {
+	r.RenderAt(model, map[int]interface{}{})
+}
+
+func (r *SpriteRenderer) RenderAt(model mgl32.Mat4, custom map[int]interface{}) {
+	r.MeshRenderer.RenderAt(r.spriteModel(model), r.spriteUniforms(custom))
+}
+
+func (r *SpriteRenderer) DebugRender(model mgl32.Mat4) {
+	r.DebugRenderAt(model, map[int]interface{}{})
+}
+
+func (r *SpriteRenderer) DebugRenderAt(model mgl32.Mat4, custom map[int]interface{}) {
+	r.MeshRenderer.DebugRenderAt(r.spriteModel(model), r.spriteUniforms(custom))
+}
+
+func (r *SpriteRenderer) Animate(model mgl32.Mat4) {}
+
+// Clone : Clones a SpriteRenderer.  The texture and mesh are shared and
+// the uniform values are shallow copied.
+func (r *SpriteRenderer) Clone() Renderer {
+	return &SpriteRenderer{
+		MeshRenderer: r.MeshRenderer.Clone().(*MeshRenderer),
+		Region:       r.Region,
+		Image:        r.Image,
+		FlipX:        r.FlipX,
+		FlipY:        r.FlipY,
+	}
+}
+
+// spriteModel : Returns the model scaled from the unit square to the size
+// of the sprite
+func (r *SpriteRenderer) spriteModel(model mgl32.Mat4) mgl32.Mat4 {
+	size := r.Size()
+	return model.Mul4(mgl32.Scale3D(size[0], size[1], 1))
+}
+
+// spriteUniforms : Returns the custom uniforms with the texture rectangle
+// of the region added, unless it is overridden
+func (r *SpriteRenderer) spriteUniforms(custom map[int]interface{}) map[int]interface{} {
+	uniforms := make(map[int]interface{}, len(custom)+1)
+	uniforms[UniformTexRect] = r.TexRect()
+	for location, value := range custom {
+		uniforms[location] = value
+	}
+	return uniforms
+}
@@ -0,0 +1,113 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+var texRectTests = []struct {
+	name     string
+	region   image.Rectangle
+	flipX    bool
+	flipY    bool
+	expected mgl32.Vec4
+}{
+	{"whole", image.Rect(0, 0, 8, 4), false, false, mgl32.Vec4{0, 0, 1, 1}},
+	{"region", image.Rect(2, 1, 6, 3), false, false, mgl32.Vec4{0.25, 0.25, 0.5, 0.5}},
+	{"flip x", image.Rect(2, 1, 6, 3), true, false, mgl32.Vec4{0.75, 0.25, -0.5, 0.5}},
+	{"flip both", image.Rect(0, 0, 4, 4), true, true, mgl32.Vec4{0.5, 1, -0.5, -1}},
+}
+
+// TestSpriteTexRect : Test the texture rectangle of sprite regions, with
+// and without flipping
+func TestSpriteTexRect(t *testing.T) {
+	if _, err := InitSoftware(8, 8); err != nil {
+		t.Fatalf("InitSoftware failed: %v", err)
+	}
+	texture := &GLTexture{Size: [2]uint32{8, 4}}
+
+	for _, tc := range texRectTests {
+		t.Run(tc.name, func(t *testing.T) {
+			renderer, err := NewSpriteRenderer(texture, tc.region)
+			if err != nil {
+				t.Fatalf("NewSpriteRenderer failed: %v", err)
+			}
+			renderer.FlipX = tc.flipX
+			renderer.FlipY = tc.flipY
+
+			if rect := renderer.TexRect(); rect != tc.expected {
+				t.Errorf("TexRect was (%v) should be (%v)", rect, tc.expected)
+			}
+		})
+	}
+}
+
+var spriteRenderTests = []struct {
+	name     string
+	region   image.Rectangle
+	flipX    bool
+	tint     mgl32.Vec4
+	alpha    float32
+	expected [4]uint8
+}{
+	{"top left", image.Rect(0, 0, 2, 2), false, mgl32.Vec4{1, 1, 1, 1}, 1, [4]uint8{255, 0, 0, 255}},
+	{"flipped", image.Rect(0, 0, 4, 2), true, mgl32.Vec4{1, 1, 1, 1}, 1, [4]uint8{0, 255, 0, 255}},
+	{"tinted", image.Rect(0, 0, 2, 2), false, mgl32.Vec4{0.5, 1, 1, 1}, 1, [4]uint8{128, 0, 0, 255}},
+	{"faded", image.Rect(2, 0, 4, 2), false, mgl32.Vec4{1, 1, 1, 1}, 0.5, [4]uint8{0, 128, 0, 255}},
+}
+
+// TestSpriteRender : Test that the sprite draws its region of the texture
+// at its pixel size, with flipping, tint and opacity
+func TestSpriteRender(t *testing.T) {
+	frame, err := InitSoftware(20, 20)
+	if err != nil {
+		t.Fatalf("InitSoftware failed: %v", err)
+	}
+	Set2DProjection(20, 20)
+
+	// Red on the left half and green on the right half
+	rgba := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			if x < 2 {
+				rgba.SetRGBA(x, y, color.RGBA{255, 0, 0, 255})
+			} else {
+				rgba.SetRGBA(x, y, color.RGBA{0, 255, 0, 255})
+			}
+		}
+	}
+	texture, err := glState.Backend.CreateTexture(rgba)
+	if err != nil {
+		t.Fatalf("CreateTexture failed: %v", err)
+	}
+
+	for _, tc := range spriteRenderTests {
+		t.Run(tc.name, func(t *testing.T) {
+			renderer, err := NewSpriteRenderer(texture, tc.region)
+			if err != nil {
+				t.Fatalf("NewSpriteRenderer failed: %v", err)
+			}
+			renderer.FlipX = tc.flipX
+			renderer.MeshRenderer.Uniforms[UniformColor] = tc.tint
+			renderer.MeshRenderer.Uniforms[UniformAlpha] = tc.alpha
+
+			ClearBackBuffer()
+			renderer.Render(mgl32.Translate3D(10, 10, 0))
+
+			// The left most pixel of the sprite
+			i := frame.PixOffset(10-tc.region.Dx()/2, 9)
+			actual := [4]uint8{frame.Pix[i], frame.Pix[i+1], frame.Pix[i+2], frame.Pix[i+3]}
+			if actual != tc.expected {
+				t.Errorf("Pixel was (%v) should be (%v)", actual, tc.expected)
+			}
+
+			outside := frame.PixOffset(10-tc.region.Dx()/2-1, 9)
+			if frame.Pix[outside] != 0 || frame.Pix[outside+1] != 0 {
+				t.Errorf("Pixel outside the sprite should be clear")
+			}
+		})
+	}
+}
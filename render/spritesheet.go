@@ -0,0 +1,371 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Frame : A region of a sprite sheet texture, in texture pixels, drawn
+// for Duration milliseconds.  Offset moves the region from the center of
+// the sprite, for frames trimmed of transparent edges.
+type Frame struct {
+	Name     string
+	Region   image.Rectangle
+	Duration int
+	Offset   mgl32.Vec2
+}
+
+// SpriteSheet : A texture divided into frames, and the animations made
+// from them
+type SpriteSheet struct {
+	Texture    *GLTexture
+	Frames     []Frame
+	Animations map[string]*Animation
+}
+
+// defaultFrameDuration : The duration in milliseconds of frames which do
+// not specify one
+const defaultFrameDuration = 100
+
+// NewSpriteSheet : Creates a sprite sheet of the frames of the texture
+func NewSpriteSheet(texture *GLTexture, frames []Frame) *SpriteSheet {
+	return &SpriteSheet{
+		Texture:    texture,
+		Frames:     frames,
+		Animations: map[string]*Animation{},
+	}
+}
+
+// NewGridSheet : Creates a sprite sheet dividing the texture into a grid
+// of frames of the same size, numbered from the top left along each row.
+// Each frame lasts duration milliseconds.
+func NewGridSheet(texture *GLTexture, frameWidth int, frameHeight int, duration int) (*SpriteSheet, error) {
+	if frameWidth <= 0 || frameHeight <= 0 {
+		return nil, fmt.Errorf("invalid frame size: %vx%v", frameWidth, frameHeight)
+	}
+
+	frames := []Frame{}
+	for y := 0; y+frameHeight <= int(texture.Size[1]); y += frameHeight {
+		for x := 0; x+frameWidth <= int(texture.Size[0]); x += frameWidth {
+			frames = append(frames, Frame{
+				Name:     fmt.Sprintf("%v", len(frames)),
+				Region:   image.Rect(x, y, x+frameWidth, y+frameHeight),
+				Duration: duration,
+			})
+		}
+	}
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("texture smaller than frame size: %vx%v", frameWidth, frameHeight)
+	}
+
+	return NewSpriteSheet(texture, frames), nil
+}
+
+// AddAnimation : Adds an animation playing the numbered frames in order,
+// each for its own duration
+func (s *SpriteSheet) AddAnimation(name string, frames []int, loop LoopMode) (*Animation, error) {
+	durations := make([]int, len(frames))
+	for i, frame := range frames {
+		if frame < 0 || frame >= len(s.Frames) {
+			return nil, fmt.Errorf("animation %q: frame %v out of range", name, frame)
+		}
+		durations[i] = s.Frames[frame].Duration
+	}
+
+	animation := &Animation{
+		Name:      name,
+		Frames:    append([]int(nil), frames...),
+		Durations: durations,
+		Loop:      loop,
+	}
+	s.Animations[name] = animation
+	return animation, nil
+}
+
+// FrameRange : Returns the frame numbers from first to last inclusive, in
+// reverse if last is before first
+func FrameRange(first int, last int) []int {
+	frames := []int{}
+	step := 1
+	if last < first {
+		step = -1
+	}
+	for i := first; i != last+step; i += step {
+		frames = append(frames, i)
+	}
+	return frames
+}
+
+/////////////////////////////////////////////////////////////
+// JSON frame lists
+//
+
+// sheetJSON : A sprite sheet as a JSON frame list, see ParseSpriteSheet
+type sheetJSON struct {
+	Image  string `json:"image"`
+	Frames []struct {
+		Name     string `json:"name"`
+		X        int    `json:"x"`
+		Y        int    `json:"y"`
+		W        int    `json:"w"`
+		H        int    `json:"h"`
+		Duration int    `json:"duration"`
+	} `json:"frames"`
+	Animations []struct {
+		Name   string `json:"name"`
+		Frames []int  `json:"frames"`
+		Loop   string `json:"loop"`
+	} `json:"animations"`
+}
+
+// LoadSpriteSheet : Loads a sprite sheet from a JSON frame list and the
+// image it names.  See ParseSpriteSheet.
+func LoadSpriteSheet(path string) (*SpriteSheet, error) {
+	return loadSheet(path, ParseSpriteSheet)
+}
+
+// ParseSpriteSheet : Returns the sprite sheet of the texture described by
+// a JSON frame list of the form:
+//
+//	{
+//	  "image": "hero.png",
+//	  "frames": [{"name": "walk0", "x": 0, "y": 0, "w": 16, "h": 16, "duration": 100}],
+//	  "animations": [{"name": "walk", "frames": [0, 1, 2], "loop": "repeat"}]
+//	}
+//
+// where loop is "repeat", "once" or "pingpong".  Frames without a
+// duration last 100 milliseconds.  Frames must have a positive width and
+// height.
+func ParseSpriteSheet(texture *GLTexture, data []byte) (*SpriteSheet, error) {
+	var parsed sheetJSON
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+
+	frames := make([]Frame, len(parsed.Frames))
+	for i, frame := range parsed.Frames {
+		region, err := frameRegion(frame.Name, frame.X, frame.Y, frame.W, frame.H)
+		if err != nil {
+			return nil, err
+		}
+		frames[i] = Frame{
+			Name:     frame.Name,
+			Region:   region,
+			Duration: frameDuration(frame.Duration),
+		}
+	}
+	sheet := NewSpriteSheet(texture, frames)
+
+	for _, animation := range parsed.Animations {
+		loop, err := ParseLoopMode(animation.Loop)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := sheet.AddAnimation(animation.Name, animation.Frames, loop); err != nil {
+			return nil, err
+		}
+	}
+
+	return sheet, nil
+}
+
+/////////////////////////////////////////////////////////////
+// Aseprite
+//
+
+type asepriteRect struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+type asepriteFrame struct {
+	Filename         string       `json:"filename"`
+	Frame            asepriteRect `json:"frame"`
+	SpriteSourceSize asepriteRect `json:"spriteSourceSize"`
+	SourceSize       asepriteRect `json:"sourceSize"`
+	Trimmed          bool         `json:"trimmed"`
+	Duration         int          `json:"duration"`
+}
+
+type asepriteJSON struct {
+	Frames json.RawMessage `json:"frames"`
+	Meta   struct {
+		Image     string `json:"image"`
+		FrameTags []struct {
+			Name      string `json:"name"`
+			From      int    `json:"from"`
+			To        int    `json:"to"`
+			Direction string `json:"direction"`
+		} `json:"frameTags"`
+	} `json:"meta"`
+}
+
+// LoadAsepriteSheet : Loads a sprite sheet exported by Aseprite, and the
+// image it names.  See ParseAsepriteSheet.
+func LoadAsepriteSheet(path string) (*SpriteSheet, error) {
+	return loadSheet(path, ParseAsepriteSheet)
+}
+
+// ParseAsepriteSheet : Returns the sprite sheet of the texture described
+// by the JSON exported by Aseprite, in either the array or hash frame
+// format.  Each frame tag becomes an animation which repeats, playing
+// forward, in reverse or ping-ponging according to its direction.
+// Trimmed frames are offset to their place in the untrimmed sprite.
+func ParseAsepriteSheet(texture *GLTexture, data []byte) (*SpriteSheet, error) {
+	var parsed asepriteJSON
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+
+	asepriteFrames, err := parseAsepriteFrames(parsed.Frames)
+	if err != nil {
+		return nil, err
+	}
+
+	frames := make([]Frame, len(asepriteFrames))
+	for i, frame := range asepriteFrames {
+		r := frame.Frame
+		region, err := frameRegion(frame.Filename, r.X, r.Y, r.W, r.H)
+		if err != nil {
+			return nil, err
+		}
+		frames[i] = Frame{
+			Name:     frame.Filename,
+			Region:   region,
+			Duration: frameDuration(frame.Duration),
+		}
+		if frame.Trimmed {
+			source := frame.SpriteSourceSize
+			frames[i].Offset = mgl32.Vec2{
+				float32(source.X) + float32(source.W)/2 - float32(frame.SourceSize.W)/2,
+				float32(frame.SourceSize.H)/2 - float32(source.Y) - float32(source.H)/2,
+			}
+		}
+	}
+	sheet := NewSpriteSheet(texture, frames)
+
+	for _, tag := range parsed.Meta.FrameTags {
+		frameNumbers := FrameRange(tag.From, tag.To)
+		loop := LoopRepeat
+		switch tag.Direction {
+		case "reverse":
+			frameNumbers = FrameRange(tag.To, tag.From)
+		case "pingpong":
+			loop = LoopPingPong
+		case "pingpong_reverse":
+			frameNumbers = FrameRange(tag.To, tag.From)
+			loop = LoopPingPong
+		}
+
+		if _, err := sheet.AddAnimation(tag.Name, frameNumbers, loop); err != nil {
+			return nil, err
+		}
+	}
+
+	return sheet, nil
+}
+
+// parseAsepriteFrames : Returns the frames of an Aseprite export in order.
+// The hash format is a JSON object keyed by file name, so it is read
+// token by token to keep the order of the keys.
+func parseAsepriteFrames(data json.RawMessage) ([]asepriteFrame, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		var frames []asepriteFrame
+		err := json.Unmarshal(data, &frames)
+		return frames, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	frames := []asepriteFrame{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var frame asepriteFrame
+		if err := decoder.Decode(&frame); err != nil {
+			return nil, err
+		}
+		frame.Filename = fmt.Sprintf("%v", token)
+		frames = append(frames, frame)
+	}
+
+	return frames, nil
+}
+
+// loadSheet : Reads the JSON file at path and parses it with the texture
+// of the image it names, relative to the JSON file.  If the image is
+// packed into an atlas the frames are moved to its region of the atlas.
+func loadSheet(path string, parse func(texture *GLTexture, data []byte) (*SpriteSheet, error)) (*SpriteSheet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var header struct {
+		Image string `json:"image"`
+		Meta  struct {
+			Image string `json:"image"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse sprite sheet %q: %v", path, err)
+	}
+
+	imagePath := header.Image
+	if imagePath == "" {
+		imagePath = header.Meta.Image
+	}
+	if imagePath == "" {
+		return nil, fmt.Errorf("sprite sheet %q names no image", path)
+	}
+
+	texture, bounds, err := CreateTextureRegion(filepath.Join(filepath.Dir(path), imagePath))
+	if err != nil {
+		return nil, err
+	}
+
+	sheet, err := parse(texture, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sprite sheet %q: %v", path, err)
+	}
+
+	for i, frame := range sheet.Frames {
+		region := frame.Region.Add(bounds.Min).Intersect(bounds)
+		if region.Empty() {
+			return nil, fmt.Errorf("sprite sheet %q: frame %q outside image", path, frame.Name)
+		}
+		sheet.Frames[i].Region = region
+	}
+	return sheet, nil
+}
+
+func frameDuration(duration int) int {
+	if duration <= 0 {
+		return defaultFrameDuration
+	}
+	return duration
+}
+
+// frameRegion : Returns the region of the named frame, which must have a
+// positive width and height
+func frameRegion(name string, x int, y int, w int, h int) (image.Rectangle, error) {
+	if w <= 0 || h <= 0 {
+		return image.Rectangle{}, fmt.Errorf("frame %q: invalid frame size: %vx%v", name, w, h)
+	}
+	return image.Rect(x, y, x+w, y+h), nil
+}
@@ -6,8 +6,6 @@ import (
 	"image/draw"
 	"os"
 
-	"github.com/go-gl/gl/v4.1-core/gl"
-
 	// Bring in png so we support this file format
 	_ "image/png"
 )
@@ -19,22 +17,20 @@ type GLTexture struct {
 }
 
 /////////////////////////////////////////////////////////////
-// OpenGL Resources
+// Texture Resources
 //
 
 // CreateTexture : use the image from the supplied path to create a texture
 func CreateTextureImpl(texturePath string) (*GLTexture, error) {
 	result, textureExists := glState.Textures[texturePath]
 	if !textureExists {
-		texture, sizeX, sizeY, err := loadTexture(
-			texturePath,
-			gl.TEXTURE0)
+		rgba, err := loadImage(texturePath)
 		if err != nil {
 			return nil, err
 		}
-		result = &GLTexture{
-			ID:   texture,
-			Size: [2]uint32{sizeX, sizeY},
+		result, err = backend().CreateTexture(rgba)
+		if err != nil {
+			return nil, err
 		}
 		glState.Textures[texturePath] = result
 	}
@@ -42,44 +38,24 @@ func CreateTextureImpl(texturePath string) (*GLTexture, error) {
 	return result, nil
 }
 
-func loadTexture(file string, textureUnit uint32) (uint32, uint32, uint32, error) {
+func loadImage(file string) (*image.RGBA, error) {
 	imgFile, err := os.Open(file)
 	if err != nil {
-		return 0, 0, 0, fmt.Errorf("failed to load texture %q: %v", file, err)
+		return nil, fmt.Errorf("failed to load texture %q: %v", file, err)
 	}
+	defer imgFile.Close()
+
 	img, _, err := image.Decode(imgFile)
 	if err != nil {
-		return 0, 0, 0, err
+		return nil, err
 	}
 
 	rgba := image.NewRGBA(img.Bounds())
 	if rgba.Stride != rgba.Rect.Size().X*4 {
-		return 0, 0, 0, fmt.Errorf("unsupported image stride")
+		return nil, fmt.Errorf("unsupported image stride")
 	}
 	draw.Draw(rgba, rgba.Bounds(), image.Transparent, image.Point{}, draw.Src)
 	draw.Draw(rgba, rgba.Bounds(), img, image.Point{}, draw.Over)
 
-	return TextureFromRGBA(rgba, textureUnit), uint32(rgba.Rect.Size().X), uint32(rgba.Rect.Size().Y), nil
-}
-
-func TextureFromRGBA(rgba *image.RGBA, textureUnit uint32) uint32 {
-	var texture uint32
-	gl.GenTextures(1, &texture)
-	gl.ActiveTexture(textureUnit)
-	gl.BindTexture(gl.TEXTURE_2D, texture)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
-	gl.TexImage2D(
-		gl.TEXTURE_2D,
-		0,
-		gl.RGBA,
-		int32(rgba.Rect.Size().X),
-		int32(rgba.Rect.Size().Y),
-		0,
-		gl.RGBA,
-		gl.UNSIGNED_BYTE,
-		gl.Ptr(rgba.Pix))
-	return texture
+	return rgba, nil
 }
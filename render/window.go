@@ -39,7 +39,6 @@ func CreateWindow(title string, width int, height int) (*glfw.Window, error) {
 	}
 
 	window.MakeContextCurrent()
-	window.SetKeyCallback(KeyCallback)
 
 	return window, nil
 }
@@ -59,15 +58,3 @@ func GetExecutablePath() (string, error) {
 
 	return executablePath, nil
 }
-
-func KeyCallback(
-	window *glfw.Window,
-	key glfw.Key,
-	scancode int,
-	action glfw.Action,
-	mod glfw.ModifierKey,
-) {
-	if action == glfw.Press && key == glfw.KeyEscape {
-		window.SetShouldClose(true)
-	}
-}
@@ -0,0 +1,195 @@
+package gologo
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Scene : Owns a set of objects and draws them in z-order.  Objects are
+// kept sorted as they are added or their z-order is changed with SetZOrder.
+// Objects with the same z-order are drawn in the order they were added,
+// except that an object whose z-order is changed is drawn after the other
+// objects with its new z-order.
+//
+// OnEnter and OnExit are called when the scene becomes, or stops being,
+// the current scene of a Gologo.  OnUpdate is called at the fixed update
+// rate while the scene is current.  All three may be nil.
+type Scene struct {
+	OnEnter  func()
+	OnExit   func()
+	OnUpdate func(dt float64)
+
+	entries []*Object
+	byID    map[int]*Object
+}
+
+// nextObjectID : The ID assigned to the next object added to a scene
+// without an ID
+var nextObjectID = 1
+
+// NewScene : Creates an empty scene
+func NewScene() *Scene {
+	return &Scene{
+		byID: map[int]*Object{},
+	}
+}
+
+// Add : Adds objects, and their descendants, to the scene.  Objects
+// without an ID are assigned a unique ID.  Adding an object already in the
+// scene has no effect.  Panics if another object in the scene has the same
+// ID.
+func (s *Scene) Add(objects ...*Object) {
+	for _, object := range objects {
+		if s.Contains(object) {
+			continue
+		}
+
+		if object.ID == 0 {
+			object.ID = nextObjectID
+		} else if _, exists := s.byID[object.ID]; exists {
+			panic(fmt.Sprintf("Failed to add object to scene: ID %v is already in use\n", object.ID))
+		}
+		if object.ID >= nextObjectID {
+			nextObjectID = object.ID + 1
+		}
+
+		object.saveState()
+		object.scenes = append(object.scenes, s)
+		s.byID[object.ID] = object
+		s.insert(object)
+
+		s.Add(object.Children...)
+	}
+}
+
+// Remove : Removes the object, and its descendants, from the scene
+func (s *Scene) Remove(object *Object) {
+	if !s.Contains(object) {
+		return
+	}
+
+	for _, child := range object.Children {
+		s.Remove(child)
+	}
+
+	s.remove(object)
+	delete(s.byID, object.ID)
+
+	for i, scene := range object.scenes {
+		if scene == s {
+			object.scenes = append(object.scenes[:i], object.scenes[i+1:]...)
+			break
+		}
+	}
+}
+
+// Find : Returns the object in the scene with the ID, or nil
+func (s *Scene) Find(id int) *Object {
+	return s.byID[id]
+}
+
+// Contains : Returns true if the object is in the scene
+func (s *Scene) Contains(object *Object) bool {
+	found, exists := s.byID[object.ID]
+	return exists && found == object
+}
+
+// Len : Returns the number of objects in the scene
+func (s *Scene) Len() int {
+	return len(s.entries)
+}
+
+// Objects : Returns the objects in the scene in z-order
+func (s *Scene) Objects() []*Object {
+	result := make([]*Object, len(s.entries))
+	copy(result, s.entries)
+	return result
+}
+
+// Pick : Returns the top-most object in the scene whose mesh contains the
+// world space point, or nil if there is none.  When objects overlap, the
+// one drawn last is returned.
+func (s *Scene) Pick(point mgl32.Vec2) *Object {
+	for i := len(s.entries) - 1; i >= 0; i-- {
+		if s.entries[i].ContainsPoint(point) {
+			return s.entries[i]
+		}
+	}
+	return nil
+}
+
+// Draw : Draws all objects in the scene in z-order
+func (s *Scene) Draw() {
+	s.DrawInterpolated(1.0)
+}
+
+// DrawInterpolated : Draws all objects in the scene in z-order,
+// interpolated by alpha between their state before the last fixed
+// update and their current state
+func (s *Scene) DrawInterpolated(alpha float64) {
+	for _, object := range s.entries {
+		if object.Renderer != nil {
+			object.DrawInterpolated(alpha)
+		}
+	}
+}
+
+func (s *Scene) saveState() {
+	for _, object := range s.entries {
+		object.saveState()
+	}
+}
+
+// insert : Inserts the object after all objects with a lower or equal
+// z-order
+func (s *Scene) insert(object *Object) {
+	i := sort.Search(len(s.entries), func(i int) bool {
+		return s.entries[i].ZOrder > object.ZOrder
+	})
+
+	s.entries = append(s.entries, nil)
+	copy(s.entries[i+1:], s.entries[i:])
+	s.entries[i] = object
+}
+
+func (s *Scene) remove(object *Object) {
+	for i, entry := range s.entries {
+		if entry == object {
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+// reorder : Moves the object to its position for its current z-order,
+// after the other objects with the same z-order
+func (s *Scene) reorder(object *Object) {
+	s.remove(object)
+	s.insert(object)
+}
+
+// Scene : Returns the current scene
+func (g *Gologo) Scene() *Scene {
+	return g.scene
+}
+
+// SetScene : Makes the scene current, calling OnExit on the previous
+// scene and OnEnter on the new scene
+func (g *Gologo) SetScene(scene *Scene) {
+	if g.scene == scene {
+		return
+	}
+
+	if g.scene != nil && g.scene.OnExit != nil {
+		g.scene.OnExit()
+	}
+
+	g.scene = scene
+	g.scene.saveState()
+
+	if g.scene.OnEnter != nil {
+		g.scene.OnEnter()
+	}
+}
@@ -0,0 +1,121 @@
+package gologo
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func zOrders(objects []*Object) []int {
+	result := []int{}
+	for _, o := range objects {
+		result = append(result, o.ZOrder)
+	}
+	return result
+}
+
+// TestSceneZOrder : Test that scene objects are kept in z-order as they
+// are added and their z-order changes, with ties in order of addition
+func TestSceneZOrder(t *testing.T) {
+	scene := NewScene()
+
+	a := CreateObject(mgl32.Vec3{})
+	a.SetZOrder(2)
+	b := CreateObject(mgl32.Vec3{})
+	b.SetZOrder(1)
+	c := CreateObject(mgl32.Vec3{})
+	c.SetZOrder(2)
+
+	scene.Add(a, b, c)
+
+	objects := scene.Objects()
+	if objects[0] != b || objects[1] != a || objects[2] != c {
+		t.Errorf("Objects were in z-order %v should be [1 2 2] with a before c", zOrders(objects))
+	}
+
+	b.SetZOrder(3)
+	objects = scene.Objects()
+	if objects[0] != a || objects[1] != c || objects[2] != b {
+		t.Errorf("After SetZOrder objects were in z-order %v should be [2 2 3]", zOrders(objects))
+	}
+}
+
+// TestSceneFindRemove : Test that objects are assigned IDs and can be
+// found and removed by them
+func TestSceneFindRemove(t *testing.T) {
+	scene := NewScene()
+	a := CreateObject(mgl32.Vec3{})
+	b := CreateObject(mgl32.Vec3{})
+	scene.Add(a, b)
+
+	if a.ID == 0 || a.ID == b.ID {
+		t.Fatalf("IDs were (%v) and (%v) should be distinct and non-zero", a.ID, b.ID)
+	}
+	if scene.Find(b.ID) != b {
+		t.Errorf("Find(%v) did not return the object", b.ID)
+	}
+
+	scene.Remove(a)
+	if scene.Find(a.ID) != nil || scene.Len() != 1 {
+		t.Errorf("Removed object was still in the scene")
+	}
+
+	// Removed objects no longer affect the scene order
+	a.SetZOrder(-1)
+	if scene.Objects()[0] != b {
+		t.Errorf("Removed object was reinserted by SetZOrder")
+	}
+}
+
+// TestSceneExplicitIDs : Test that objects added with an ID are not
+// overwritten by objects assigned an ID, and that duplicate IDs panic
+func TestSceneExplicitIDs(t *testing.T) {
+	scene := NewScene()
+	a := CreateObject(mgl32.Vec3{})
+	a.ID = nextObjectID + 5
+	scene.Add(a)
+
+	for i := 0; i < 10; i++ {
+		scene.Add(CreateObject(mgl32.Vec3{}))
+	}
+	if !scene.Contains(a) || scene.Find(a.ID) != a {
+		t.Errorf("Object with ID (%v) was replaced by an assigned ID", a.ID)
+	}
+
+	scene.Remove(a)
+	if scene.Len() != 10 {
+		t.Errorf("Scene length after Remove was (%v) should be (10)", scene.Len())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Adding a duplicate ID should panic")
+		}
+	}()
+	b := CreateObject(mgl32.Vec3{})
+	b.ID = scene.Objects()[0].ID
+	scene.Add(b)
+}
+
+// TestSetScene : Test that switching scenes calls the exit and enter hooks
+func TestSetScene(t *testing.T) {
+	g := InitWithConfig(Config{Width: 64, Height: 64, Headless: true})
+	defer g.Close()
+
+	calls := []string{}
+	menu := NewScene()
+	menu.OnEnter = func() { calls = append(calls, "enter menu") }
+	menu.OnExit = func() { calls = append(calls, "exit menu") }
+	game := NewScene()
+	game.OnEnter = func() { calls = append(calls, "enter game") }
+
+	g.SetScene(menu)
+	g.SetScene(game)
+
+	if len(calls) != 3 || calls[0] != "enter menu" || calls[1] != "exit menu" || calls[2] != "enter game" {
+		t.Errorf("Hooks called were %v should be [enter menu exit menu enter game]", calls)
+	}
+	if g.Scene() != game {
+		t.Errorf("Current scene was not the game scene")
+	}
+}
@@ -0,0 +1,224 @@
+package gologo
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo/render"
+)
+
+// Script : A sequence of steps which plays out over many updates, written
+// as straight-line code:
+//
+//	g.StartScript(func(s *gologo.Script) {
+//		s.MoveTo(square, 400, 300, 500)
+//		s.Wait(200)
+//		s.Rotate(square, math.Pi, 250)
+//	})
+//
+// Each script runs in its own goroutine, but only while the game loop is
+// waiting for it, so scripts may freely read and change objects.  While a
+// script runs, every call it makes to the graphics backend, such as
+// creating an object, texture or text, is passed back to the main thread
+// as if made with Do.
+//
+// Scripts are run in the order they were started, once per fixed update,
+// after the update functions.  Durations are measured in simulated time,
+// so scripts do not advance while the game clock is paused.
+type Script struct {
+	fn        func(s *Script)
+	resume    chan bool
+	yield     chan scriptYield
+	time      float64
+	wakeAt    float64
+	cancelled bool
+	done      bool
+}
+
+// scriptYield : Sent by a script when it hands control back to the game
+// loop.  do is set when the script is waiting for a function to be called
+// on the main thread.  panic is set if the script panicked.
+type scriptYield struct {
+	do    func()
+	done  bool
+	panic interface{}
+}
+
+// errScriptCancelled : Unwinds a cancelled script from its current step
+var errScriptCancelled = fmt.Errorf("script cancelled")
+
+// StartScript : Starts running fn as a script from the next update
+func (g *Gologo) StartScript(fn func(s *Script)) *Script {
+	s := &Script{
+		fn:     fn,
+		resume: make(chan bool),
+		yield:  make(chan scriptYield),
+	}
+	go s.run()
+
+	g.scripts = append(g.scripts, s)
+	return s
+}
+
+// runScripts : Advances the scripts by dt seconds and runs those which
+// are due until they next wait
+func (g *Gologo) runScripts(dt float64) {
+	scripts := append([]*Script{}, g.scripts...)
+
+	for _, s := range scripts {
+		if s.done {
+			continue
+		}
+
+		s.time += dt
+		if s.cancelled || s.time >= s.wakeAt-stepTolerance {
+			s.step()
+		}
+	}
+
+	active := g.scripts[:0]
+	for _, s := range g.scripts {
+		if !s.done {
+			active = append(active, s)
+		}
+	}
+	g.scripts = active
+}
+
+// step : Runs the script until it next waits or finishes, calling any
+// functions it passes to Do, and any calls it makes to the graphics
+// backend, on the main thread
+func (s *Script) step() {
+	for {
+		render.SetDispatcher(s.Do)
+		s.resume <- !s.cancelled
+		y := <-s.yield
+		render.SetDispatcher(nil)
+
+		if y.done {
+			s.done = true
+			if y.panic != nil {
+				panic(y.panic)
+			}
+			return
+		}
+
+		if y.do == nil {
+			return
+		}
+		y.do()
+	}
+}
+
+func (s *Script) run() {
+	defer func() {
+		r := recover()
+		if r == nil || r == errScriptCancelled {
+			s.yield <- scriptYield{done: true}
+			return
+		}
+		s.yield <- scriptYield{
+			done:  true,
+			panic: fmt.Sprintf("script panicked: %v\n%s", r, debug.Stack()),
+		}
+	}()
+
+	if <-s.resume {
+		s.fn(s)
+	}
+}
+
+// wait : Hands control back to the game loop until the script time
+// reaches wakeAt
+func (s *Script) wait(wakeAt float64) {
+	s.wakeAt = wakeAt
+	s.handBack(scriptYield{})
+}
+
+func (s *Script) handBack(y scriptYield) {
+	s.yield <- y
+	if !<-s.resume {
+		panic(errScriptCancelled)
+	}
+}
+
+// Cancel : Stops the script at its next step.  May be called from the
+// script itself or from the game loop.
+func (s *Script) Cancel() {
+	s.cancelled = true
+}
+
+// Done : Returns true once the script has finished or been cancelled
+func (s *Script) Done() bool {
+	return s.done
+}
+
+// Time : Returns the simulated time in seconds since the script started
+func (s *Script) Time() float64 {
+	return s.time
+}
+
+// Wait : Waits for ms milliseconds
+func (s *Script) Wait(ms int) {
+	s.wait(s.time + float64(ms)/1000)
+}
+
+// WaitUpdate : Waits until the next update
+func (s *Script) WaitUpdate() {
+	s.wait(s.time)
+}
+
+// WaitUntil : Waits until condition returns true, checking once per update
+func (s *Script) WaitUntil(condition func() bool) {
+	for !condition() {
+		s.WaitUpdate()
+	}
+}
+
+// Do : Calls fn on the main thread and waits for it to return
+func (s *Script) Do(fn func()) {
+	s.handBack(scriptYield{do: fn})
+}
+
+// Over : Calls fn once per update with the fraction of ms milliseconds
+// elapsed, from 0 until it reaches 1
+func (s *Script) Over(ms int, fn func(f float64)) {
+	start := s.time
+	duration := float64(ms) / 1000
+
+	for {
+		f := 1.0
+		if duration > 0 {
+			f = (s.time - start) / duration
+		}
+		if f >= 1.0-stepTolerance {
+			f = 1.0
+		}
+
+		fn(f)
+		if f >= 1.0 {
+			return
+		}
+		s.WaitUpdate()
+	}
+}
+
+// MoveTo : Moves the object in a straight line to x, y over ms milliseconds
+func (s *Script) MoveTo(object *Object, x float32, y float32, ms int) {
+	start := object.Position
+	end := mgl32.Vec3{x, y, start.Z()}
+
+	s.Over(ms, func(f float64) {
+		object.Position = start.Add(end.Sub(start).Mul(float32(f)))
+	})
+}
+
+// Rotate : Rotates the object by angle radians over ms milliseconds
+func (s *Script) Rotate(object *Object, angle float64, ms int) {
+	start := object.Orientation
+
+	s.Over(ms, func(f float64) {
+		object.Orientation = start + angle*f
+	})
+}
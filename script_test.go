@@ -0,0 +1,200 @@
+package gologo
+
+import (
+	"image"
+	"math"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo/render"
+	"github.com/leedenison/gologo/time"
+)
+
+// stepScripts : Steps the headless loop n times, 10ms at a time
+func stepScripts(g *Gologo, clock *time.ManualClock, n int) {
+	for i := 0; i < n; i++ {
+		clock.Step(10)
+		g.Step()
+	}
+}
+
+func scriptGologo() (*Gologo, *time.ManualClock) {
+	clock := &time.ManualClock{}
+	g := InitWithConfig(Config{Width: 64, Height: 64, Headless: true, UpdateRate: 100, Clock: clock})
+	return g, clock
+}
+
+// TestScriptSequence : Test that script steps play out over updates
+func TestScriptSequence(t *testing.T) {
+	g, clock := scriptGologo()
+	defer g.Close()
+
+	o := CreateObject(mgl32.Vec3{0, 0, 0})
+	s := g.StartScript(func(s *Script) {
+		s.MoveTo(o, 100, 50, 100)
+		s.Wait(50)
+		s.Rotate(o, math.Pi, 100)
+	})
+
+	var scriptTests = []struct {
+		name        string
+		steps       int
+		position    mgl32.Vec3
+		orientation float64
+	}{
+		{"start", 1, mgl32.Vec3{0, 0, 0}, 0},
+		{"halfway", 5, mgl32.Vec3{50, 25, 0}, 0},
+		{"arrived", 5, mgl32.Vec3{100, 50, 0}, 0},
+		{"waiting", 5, mgl32.Vec3{100, 50, 0}, 0},
+		{"rotating", 5, mgl32.Vec3{100, 50, 0}, math.Pi / 2},
+		{"finished", 5, mgl32.Vec3{100, 50, 0}, math.Pi},
+	}
+
+	for _, test := range scriptTests {
+		t.Run(test.name, func(t *testing.T) {
+			stepScripts(g, clock, test.steps)
+			if !o.Position.ApproxEqualThreshold(test.position, epsilon) {
+				t.Errorf("Position was (%v) should be (%v)", o.Position, test.position)
+			}
+			if math.Abs(o.Orientation-test.orientation) > epsilon {
+				t.Errorf("Orientation was (%v) should be (%v)", o.Orientation, test.orientation)
+			}
+		})
+	}
+
+	if !s.Done() {
+		t.Errorf("Script should be done")
+	}
+}
+
+// TestScriptsConcurrent : Test that scripts interleave in the order they
+// were started and that cancelled scripts stop
+func TestScriptsConcurrent(t *testing.T) {
+	g, clock := scriptGologo()
+	defer g.Close()
+
+	log := []string{}
+	g.StartScript(func(s *Script) {
+		for i := 0; i < 3; i++ {
+			log = append(log, "a")
+			s.WaitUpdate()
+		}
+	})
+	b := g.StartScript(func(s *Script) {
+		for {
+			s.Do(func() {
+				log = append(log, "b")
+			})
+			s.WaitUpdate()
+		}
+	})
+
+	stepScripts(g, clock, 2)
+	b.Cancel()
+	stepScripts(g, clock, 2)
+
+	expected := []string{"a", "b", "a", "b", "a"}
+	if !reflect.DeepEqual(log, expected) {
+		t.Errorf("Log was (%v) should be (%v)", log, expected)
+	}
+	if !b.Done() {
+		t.Errorf("Cancelled script should be done")
+	}
+}
+
+// TestScriptPanic : Test that a panic in a script is raised on the main thread
+func TestScriptPanic(t *testing.T) {
+	g, clock := scriptGologo()
+	defer g.Close()
+
+	g.StartScript(func(s *Script) {
+		panic("oops")
+	})
+
+	defer func() {
+		r := recover()
+		if r == nil || !strings.Contains(r.(string), "oops") {
+			t.Errorf("Panic was (%v) should contain (oops)", r)
+		}
+	}()
+
+	stepScripts(g, clock, 1)
+}
+
+// goroutineID : Returns the ID of the calling goroutine
+func goroutineID() string {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	return strings.Fields(string(buf))[1]
+}
+
+// threadBackend : Records the goroutine of each call which creates
+// resources on the backend
+type threadBackend struct {
+	render.Backend
+	goroutines []string
+}
+
+func (b *threadBackend) CompileProgram(vertexShader string, fragmentShader string) (*render.GLShader, error) {
+	b.goroutines = append(b.goroutines, goroutineID())
+	return b.Backend.CompileProgram(vertexShader, fragmentShader)
+}
+
+func (b *threadBackend) CreateMeshBuffer(shader *render.GLShader, vertices []float32) (uint32, error) {
+	b.goroutines = append(b.goroutines, goroutineID())
+	return b.Backend.CreateMeshBuffer(shader, vertices)
+}
+
+func (b *threadBackend) CreateTexture(rgba *image.RGBA) (*render.GLTexture, error) {
+	b.goroutines = append(b.goroutines, goroutineID())
+	return b.Backend.CreateTexture(rgba)
+}
+
+// TestScriptBackendCalls : Test that objects and glyphs created by a
+// script without Do are created on the main thread
+func TestScriptBackendCalls(t *testing.T) {
+	g, clock := scriptGologo()
+	defer g.Close()
+
+	backend := &threadBackend{Backend: render.NewSoftwareBackend(64, 64)}
+	if err := render.Init(backend); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	var created *render.MeshRenderer
+	g.StartScript(func(s *Script) {
+		renderer, err := render.CreateMeshRenderer(
+			"ORTHO_VERTEX_SHADER",
+			"COLOR_FRAGMENT_SHADER",
+			[]int{render.UniformColor},
+			map[int]interface{}{render.UniformColor: mgl32.Vec4{1, 1, 1, 1}},
+			[]float32{0, 0, 0, 0, 0, 1, 0, 0, 1, 0, 0, 1, 0, 0, 1})
+		if err != nil {
+			panic(err)
+		}
+		created = renderer
+
+		face, err := render.DefaultFont().Face(12)
+		if err != nil {
+			panic(err)
+		}
+		face.Glyph('g')
+	})
+	stepScripts(g, clock, 1)
+
+	if created == nil {
+		t.Fatalf("Script did not create the renderer")
+	}
+	if len(backend.goroutines) < 3 {
+		t.Fatalf("Backend calls were (%v) should include a program, mesh and texture", backend.goroutines)
+	}
+	main := goroutineID()
+	for _, id := range backend.goroutines {
+		if id != main {
+			t.Errorf("Backend call was made on goroutine (%v) should be (%v)", id, main)
+		}
+	}
+}
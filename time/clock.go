@@ -0,0 +1,172 @@
+package time
+
+import (
+	gotime "time"
+
+	"github.com/go-gl/glfw/v3.2/glfw"
+)
+
+// Clock : A source of time in seconds from an arbitrary zero
+type Clock interface {
+	Now() float64
+}
+
+// GLFWClock : Reads the GLFW timer.  Requires GLFW to be initialised.
+type GLFWClock struct{}
+
+func (c GLFWClock) Now() float64 {
+	return glfw.GetTime()
+}
+
+// SystemClock : Reads the system clock, for use without a window
+type SystemClock struct {
+	start gotime.Time
+}
+
+// NewSystemClock : Creates a system clock starting from zero now
+func NewSystemClock() *SystemClock {
+	return &SystemClock{start: gotime.Now()}
+}
+
+func (c *SystemClock) Now() float64 {
+	return gotime.Since(c.start).Seconds()
+}
+
+// ManualClock : Only advances when stepped, for tests and replays
+type ManualClock struct {
+	Time float64
+}
+
+func (c *ManualClock) Now() float64 {
+	return c.Time
+}
+
+// Step : Advances the clock by ms milliseconds
+func (c *ManualClock) Step(ms int) {
+	c.Time += float64(ms) / 1000
+}
+
+// ScaledClock : Runs at a multiple of its source clock and can be paused,
+// resumed and stepped.  The game clock is a ScaledClock.
+type ScaledClock struct {
+	Source Clock
+
+	scale   float64
+	paused  bool
+	started bool
+	last    float64
+	time    float64
+}
+
+// NewScaledClock : Creates a clock running at the same rate as source.
+// The source is first read when the clock is first read.
+func NewScaledClock(source Clock) *ScaledClock {
+	return &ScaledClock{
+		Source: source,
+		scale:  1.0,
+	}
+}
+
+// Now : Returns the scaled time elapsed on the source clock while the
+// clock was not paused, plus any steps
+func (c *ScaledClock) Now() float64 {
+	source := c.Source.Now()
+	if !c.started {
+		c.last = source
+		c.started = true
+	}
+	if !c.paused {
+		c.time += (source - c.last) * c.scale
+	}
+	c.last = source
+	return c.time
+}
+
+// Pause : Stops the clock advancing with its source
+func (c *ScaledClock) Pause() {
+	c.Now()
+	c.paused = true
+}
+
+// Resume : Restarts the clock advancing with its source
+func (c *ScaledClock) Resume() {
+	c.Now()
+	c.paused = false
+}
+
+// IsPaused : Returns true if the clock is paused
+func (c *ScaledClock) IsPaused() bool {
+	return c.paused
+}
+
+// SetScale : Sets the rate of the clock relative to its source, for
+// example 0.5 for slow motion
+func (c *ScaledClock) SetScale(scale float64) {
+	c.Now()
+	c.scale = scale
+}
+
+// GetScale : Returns the rate of the clock relative to its source
+func (c *ScaledClock) GetScale() float64 {
+	return c.scale
+}
+
+// Step : Advances the clock by ms milliseconds, whether or not it is paused
+func (c *ScaledClock) Step(ms int) {
+	c.Now()
+	c.time += float64(ms) / 1000
+}
+
+/////////////////////////////////////////////////////////////
+// Game clock
+//
+
+// gameClock : The clock read by Tick and GetTime.  GLFW must be
+// initialised before it is first read.
+var gameClock = NewScaledClock(GLFWClock{})
+
+// SetClock : Sets the source of the game clock and restarts the tick from
+// zero.  The pause state and scale of the game clock are kept.
+func SetClock(source Clock) {
+	gameClock.Source = source
+	gameClock.started = false
+	gameClock.time = 0
+
+	InitTick()
+}
+
+// GetClock : Returns the game clock
+func GetClock() *ScaledClock {
+	return gameClock
+}
+
+// Pause : Pauses the game clock, so tick time stops advancing
+func Pause() {
+	gameClock.Pause()
+}
+
+// Resume : Resumes the game clock
+func Resume() {
+	gameClock.Resume()
+}
+
+// IsPaused : Returns true if the game clock is paused
+func IsPaused() bool {
+	return gameClock.IsPaused()
+}
+
+// SetScale : Sets the rate of the game clock, for example 0.5 for slow motion
+func SetScale(scale float64) {
+	gameClock.SetScale(scale)
+}
+
+// GetScale : Returns the rate of the game clock
+func GetScale() float64 {
+	return gameClock.GetScale()
+}
+
+// Step : Advances the game clock by ms milliseconds, even when paused.
+// Used to advance a paused game a single frame at a time.
+func Step(ms int) {
+	gameClock.Step(ms)
+}
@@ -0,0 +1,54 @@
+package time
+
+import (
+	"testing"
+)
+
+// TestGameClock : Test that the tick time follows the source clock and
+// respects pause, scale and step
+func TestGameClock(t *testing.T) {
+	source := &ManualClock{}
+	SetClock(source)
+	defer func() {
+		Resume()
+		SetScale(1.0)
+	}()
+
+	var clockTests = []struct {
+		name   string
+		action func()
+		tick   int
+	}{
+		{"start", func() {}, 0},
+		{"advance", func() { source.Step(100) }, 100},
+		{"paused", func() { Pause(); source.Step(100) }, 100},
+		{"step while paused", func() { Step(16) }, 116},
+		{"resumed", func() { Resume(); source.Step(50) }, 166},
+		{"half speed", func() { SetScale(0.5); source.Step(100) }, 216},
+	}
+
+	for _, test := range clockTests {
+		t.Run(test.name, func(t *testing.T) {
+			test.action()
+			Tick()
+			if got := GetTickTime(); got != test.tick {
+				t.Errorf("Tick time was (%v) should be (%v)", got, test.tick)
+			}
+		})
+	}
+}
+
+// TestPausedInterval : Test that no time passes between ticks while paused
+func TestPausedInterval(t *testing.T) {
+	source := &ManualClock{}
+	SetClock(source)
+	defer Resume()
+
+	Pause()
+	source.Step(500)
+	Tick()
+
+	if TimeState.Interval != 0 {
+		t.Errorf("Interval was (%v) should be (0)", TimeState.Interval)
+	}
+}
@@ -1,6 +1,8 @@
 package time
 
-import "github.com/go-gl/glfw/v3.2/glfw"
+import (
+	"math"
+)
 
 var TimeState = TickState{}
 
@@ -16,22 +18,41 @@ type TickState struct {
 }
 
 func InitTick() error {
-	TimeState.Zero = glfw.GetTime()
+	TimeState.Zero = gameClock.Now()
 	TimeState.End = TimeState.Zero
 
 	return nil
 }
 
+// InitHeadlessTick : Initialises the tick from the system clock for use
+// without a window, when the GLFW timer is unavailable
+func InitHeadlessTick() error {
+	SetClock(NewSystemClock())
+	return nil
+}
+
+// GetTime : Returns the current game clock time in milliseconds
 func GetTime() int {
-	return int(1000 * (glfw.GetTime() - TimeState.Zero))
+	return toMillis(gameClock.Now() - TimeState.Zero)
 }
 
+// GetTickTime : Returns the game clock time in milliseconds at the last tick
 func GetTickTime() int {
-	return int(1000 * (TimeState.End - TimeState.Zero))
+	return toMillis(TimeState.End - TimeState.Zero)
 }
 
+// toMillis : Rounds seconds to the nearest millisecond, so that clocks
+// stepped in whole milliseconds report exact tick times
+func toMillis(seconds float64) int {
+	return int(math.Round(1000 * seconds))
+}
+
+// Tick : Advances the tick time to the game clock and fires any timers
+// which are due
 func Tick() {
-	time := glfw.GetTime()
+	time := gameClock.Now()
 	TimeState.Interval = time - TimeState.End
 	TimeState.End = time
+
+	runTimers()
 }
@@ -0,0 +1,146 @@
+package time
+
+import (
+	"sort"
+)
+
+// Timer : A callback scheduled on the game clock.  Timers fire from Tick,
+// so they do not fire while the game clock is paused.
+type Timer struct {
+	due       int
+	interval  int
+	repeat    bool
+	seq       int
+	callback  func()
+	remaining int
+	paused    bool
+	done      bool
+}
+
+// scheduler : Stores the scheduled timers in the order they were created
+type scheduler struct {
+	timers  []*Timer
+	nextSeq int
+}
+
+var timerState = &scheduler{}
+
+// After : Calls fn once, ms milliseconds of game time from now
+func After(ms int, fn func()) *Timer {
+	return schedule(ms, false, fn)
+}
+
+// Every : Calls fn every ms milliseconds of game time, starting ms
+// milliseconds from now.  If more than one interval passes between ticks
+// fn is called once for each interval.  An interval of zero or less calls
+// fn once per tick.
+func Every(ms int, fn func()) *Timer {
+	return schedule(ms, true, fn)
+}
+
+// CancelAll : Cancels every scheduled timer
+func CancelAll() {
+	for _, timer := range timerState.timers {
+		timer.done = true
+	}
+	timerState.timers = nil
+}
+
+func schedule(ms int, repeat bool, fn func()) *Timer {
+	timer := &Timer{
+		due:      GetTickTime() + ms,
+		interval: ms,
+		repeat:   repeat,
+		seq:      timerState.nextSeq,
+		callback: fn,
+	}
+	timerState.nextSeq++
+	timerState.timers = append(timerState.timers, timer)
+	return timer
+}
+
+// Cancel : Stops the timer from firing again
+func (t *Timer) Cancel() {
+	t.done = true
+}
+
+// Pause : Stops the timer counting down until it is resumed
+func (t *Timer) Pause() {
+	if t.paused || t.done {
+		return
+	}
+	t.remaining = t.due - GetTickTime()
+	t.paused = true
+}
+
+// Resume : Continues counting down from where the timer was paused
+func (t *Timer) Resume() {
+	if !t.paused {
+		return
+	}
+	t.due = GetTickTime() + t.remaining
+	t.paused = false
+}
+
+// IsActive : Returns true if the timer will fire again
+func (t *Timer) IsActive() bool {
+	return !t.done
+}
+
+// IsPaused : Returns true if the timer is paused
+func (t *Timer) IsPaused() bool {
+	return t.paused
+}
+
+// runTimers : Fires every timer due at or before the tick time, in order
+// of the time they were due and then the order they were created.  Timers
+// created by a callback are first considered on the following tick.
+func runTimers() {
+	now := GetTickTime()
+
+	due := []*Timer{}
+	for _, timer := range timerState.timers {
+		if !timer.done && !timer.paused && timer.due <= now {
+			due = append(due, timer)
+		}
+	}
+
+	for len(due) > 0 {
+		sort.SliceStable(due, func(i, j int) bool {
+			if due[i].due != due[j].due {
+				return due[i].due < due[j].due
+			}
+			return due[i].seq < due[j].seq
+		})
+
+		timer := due[0]
+		due = due[1:]
+
+		if timer.done || timer.paused {
+			continue
+		}
+
+		if timer.repeat {
+			if timer.interval > 0 {
+				timer.due += timer.interval
+			} else {
+				timer.due = now + 1
+			}
+			if timer.due <= now {
+				due = append(due, timer)
+			}
+		} else {
+			timer.done = true
+		}
+
+		timer.callback()
+	}
+
+	active := timerState.timers[:0]
+	for _, timer := range timerState.timers {
+		if !timer.done {
+			active = append(active, timer)
+		}
+	}
+	timerState.timers = active
+}
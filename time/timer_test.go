@@ -0,0 +1,78 @@
+package time
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestTimers : Test that timers fire in order at their due tick times
+func TestTimers(t *testing.T) {
+	source := &ManualClock{}
+	SetClock(source)
+	defer CancelAll()
+
+	fired := []string{}
+	After(50, func() { fired = append(fired, "after") })
+	every := Every(20, func() { fired = append(fired, "every") })
+	cancelled := After(10, func() { fired = append(fired, "cancelled") })
+	cancelled.Cancel()
+
+	var timerTests = []struct {
+		step  int
+		fired []string
+	}{
+		{10, []string{}},
+		{10, []string{"every"}},
+		{35, []string{"every", "every", "after"}},
+		{20, []string{"every", "every", "after", "every"}},
+	}
+
+	for _, test := range timerTests {
+		source.Step(test.step)
+		Tick()
+		if !reflect.DeepEqual(fired, test.fired) {
+			t.Errorf("At %v fired was (%v) should be (%v)", GetTickTime(), fired, test.fired)
+		}
+	}
+
+	if cancelled.IsActive() || !every.IsActive() {
+		t.Errorf("Active was (cancelled %v, every %v) should be (false, true)",
+			cancelled.IsActive(), every.IsActive())
+	}
+}
+
+// TestTimerPause : Test that paused timers and timers on a paused clock
+// do not fire
+func TestTimerPause(t *testing.T) {
+	source := &ManualClock{}
+	SetClock(source)
+	defer CancelAll()
+	defer Resume()
+
+	count := 0
+	timer := After(100, func() { count++ })
+
+	source.Step(60)
+	Tick()
+	timer.Pause()
+	source.Step(100)
+	Tick()
+	if count != 0 {
+		t.Errorf("Paused timer fired (%v) times should be (0)", count)
+	}
+
+	timer.Resume()
+	Pause()
+	source.Step(100)
+	Tick()
+	if count != 0 {
+		t.Errorf("Timer on paused clock fired (%v) times should be (0)", count)
+	}
+
+	Resume()
+	source.Step(40)
+	Tick()
+	if count != 1 {
+		t.Errorf("Resumed timer fired (%v) times should be (1)", count)
+	}
+}
@@ -0,0 +1,99 @@
+package tween
+
+import (
+	"math"
+)
+
+// Easing : Maps the linear progress of a tween, from 0 to 1, to the eased
+// progress.  Eased progress starts at 0 and ends at 1 but may overshoot
+// in between.
+type Easing func(t float64) float64
+
+// Linear : Constant speed
+func Linear(t float64) float64 {
+	return t
+}
+
+func quadIn(t float64) float64 {
+	return t * t
+}
+
+func cubicIn(t float64) float64 {
+	return t * t * t
+}
+
+func elasticIn(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	const period = 0.3
+	return -math.Pow(2, 10*(t-1)) * math.Sin((t-1-period/4)*2*math.Pi/period)
+}
+
+func bounceIn(t float64) float64 {
+	return 1 - bounceOut(1-t)
+}
+
+func bounceOut(t float64) float64 {
+	const n = 7.5625
+	const d = 2.75
+
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	default:
+		t -= 2.625 / d
+		return n*t*t + 0.984375
+	}
+}
+
+func backIn(t float64) float64 {
+	const overshoot = 1.70158
+	return t * t * ((overshoot+1)*t - overshoot)
+}
+
+// out : Returns the easing which is the reverse of in
+func out(in Easing) Easing {
+	return func(t float64) float64 {
+		return 1 - in(1-t)
+	}
+}
+
+// inOut : Returns the easing which applies in to the first half and its
+// reverse to the second half
+func inOut(in Easing) Easing {
+	return func(t float64) float64 {
+		if t < 0.5 {
+			return in(2*t) / 2
+		}
+		return 1 - in(2-2*t)/2
+	}
+}
+
+var (
+	QuadIn    Easing = quadIn
+	QuadOut          = out(quadIn)
+	QuadInOut        = inOut(quadIn)
+
+	CubicIn    Easing = cubicIn
+	CubicOut          = out(cubicIn)
+	CubicInOut        = inOut(cubicIn)
+
+	ElasticIn    Easing = elasticIn
+	ElasticOut          = out(elasticIn)
+	ElasticInOut        = inOut(elasticIn)
+
+	BounceIn    Easing = bounceIn
+	BounceOut   Easing = bounceOut
+	BounceInOut        = inOut(bounceIn)
+
+	BackIn    Easing = backIn
+	BackOut          = out(backIn)
+	BackInOut        = inOut(backIn)
+)
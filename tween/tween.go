@@ -0,0 +1,426 @@
+// Package tween animates object properties over time with easing curves.
+//
+// A tween changes a property from its value when the tween starts to a
+// target value over a duration in milliseconds of game time:
+//
+//	tween.Position(square, mgl32.Vec3{400, 300, 0}, 500).
+//		Ease(tween.QuadOut).
+//		Then(tween.Orientation(square, math.Pi, 250)).
+//		OnComplete(func() { fmt.Println("done") }).
+//		Start()
+//
+// Tweens may be combined with Then, Sequence and Parallel, and repeated
+// with Repeat and Yoyo.  Started tweens are advanced every tick by the game
+// clock, so they stop when the clock is paused.
+package tween
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+	"github.com/leedenison/gologo/render"
+	"github.com/leedenison/gologo/time"
+)
+
+// Forever : Passed to Repeat to repeat a tween until it is stopped
+const Forever = -1
+
+// maxDuration : The duration of a tween which repeats forever
+const maxDuration = math.MaxInt32
+
+// Tween : Animates a property over a duration, or combines other tweens in
+// sequence or in parallel.  Tweens are configured with chained methods
+// before Start is called.
+type Tween struct {
+	duration   int
+	easing     Easing
+	begin      func()
+	apply      func(f float64)
+	children   []*Tween
+	parallel   bool
+	repeat     int
+	yoyo       bool
+	onComplete []func()
+
+	begun     bool
+	completed bool
+	running   bool
+	startTick int
+}
+
+var running = []*Tween{}
+var driver *time.Timer
+
+/////////////////////////////////////////////////////////////
+// Properties
+//
+
+// New : Creates a tween which calls apply with the eased progress from 0
+// to 1 over ms milliseconds.  begin, if not nil, is called when the tween
+// starts, so that start values can be captured.
+func New(ms int, begin func(), apply func(f float64)) *Tween {
+	return &Tween{
+		duration: ms,
+		easing:   Linear,
+		begin:    begin,
+		apply:    apply,
+	}
+}
+
+// Float : Tweens the value read by get and written by set to the target
+func Float(get func() float64, set func(float64), to float64, ms int) *Tween {
+	var from float64
+	return New(ms,
+		func() { from = get() },
+		func(f float64) { set(from + (to-from)*f) })
+}
+
+// Vec4 : Tweens the value read by get and written by set to the target
+func Vec4(get func() mgl32.Vec4, set func(mgl32.Vec4), to mgl32.Vec4, ms int) *Tween {
+	var from mgl32.Vec4
+	return New(ms,
+		func() { from = get() },
+		func(f float64) { set(from.Add(to.Sub(from).Mul(float32(f)))) })
+}
+
+// Position : Tweens the position of the object to the target
+func Position(object *gologo.Object, to mgl32.Vec3, ms int) *Tween {
+	var from mgl32.Vec3
+	return New(ms,
+		func() { from = object.Position },
+		func(f float64) { object.Position = from.Add(to.Sub(from).Mul(float32(f))) })
+}
+
+// Orientation : Tweens the orientation of the object to the target in radians
+func Orientation(object *gologo.Object, to float64, ms int) *Tween {
+	return Float(
+		func() float64 { return object.Orientation },
+		func(v float64) { object.Orientation = v },
+		to, ms)
+}
+
+// Scale : Tweens the scale of the object to the target
+func Scale(object *gologo.Object, to float64, ms int) *Tween {
+	return Float(
+		func() float64 { return object.Scale },
+		func(v float64) { object.Scale = v },
+		to, ms)
+}
+
+// Color : Tweens the UniformColor of the object's MeshRenderer to the target
+func Color(object *gologo.Object, to mgl32.Vec4, ms int) *Tween {
+	uniforms := meshUniforms(object)
+	return Vec4(
+		func() mgl32.Vec4 {
+			color, _ := uniforms[render.UniformColor].(mgl32.Vec4)
+			return color
+		},
+		func(v mgl32.Vec4) { uniforms[render.UniformColor] = v },
+		to, ms)
+}
+
+// Alpha : Tweens the opacity of the object's MeshRenderer to the target.
+// Tweens UniformAlpha if the renderer has one, otherwise the alpha
+// component of UniformColor.
+func Alpha(object *gologo.Object, to float64, ms int) *Tween {
+	uniforms := meshUniforms(object)
+
+	if _, ok := uniforms[render.UniformAlpha]; ok {
+		return Float(
+			func() float64 { return uniformFloat(uniforms[render.UniformAlpha]) },
+			func(v float64) { uniforms[render.UniformAlpha] = float32(v) },
+			to, ms)
+	}
+
+	return Float(
+		func() float64 {
+			color, _ := uniforms[render.UniformColor].(mgl32.Vec4)
+			return float64(color.W())
+		},
+		func(v float64) {
+			color, _ := uniforms[render.UniformColor].(mgl32.Vec4)
+			color[3] = float32(v)
+			uniforms[render.UniformColor] = color
+		},
+		to, ms)
+}
+
+// Wait : Creates a tween which does nothing for ms milliseconds, to add a
+// pause to a sequence
+func Wait(ms int) *Tween {
+	return New(ms, nil, nil)
+}
+
+func meshUniforms(object *gologo.Object) map[int]interface{} {
+	switch r := object.Renderer.(type) {
+	case *render.MeshRenderer:
+		return r.Uniforms
+	case *render.BitmapRenderer:
+		return r.MeshRenderer.Uniforms
+	case *render.TextRenderer:
+		return r.Uniforms
+	case *render.SpriteRenderer:
+		return r.MeshRenderer.Uniforms
+	case *render.AnimationRenderer:
+		return r.Sprite.MeshRenderer.Uniforms
+	}
+	panic(fmt.Sprintf("Cannot tween uniforms of renderer: %T\n", object.Renderer))
+}
+
+func uniformFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 1.0
+}
+
+/////////////////////////////////////////////////////////////
+// Composition
+//
+
+// Sequence : Creates a tween which plays the tweens one after another.
+// Each tween captures its start values when it starts.
+func Sequence(tweens ...*Tween) *Tween {
+	return &Tween{children: tweens}
+}
+
+// Parallel : Creates a tween which plays the tweens at the same time,
+// finishing when the longest finishes
+func Parallel(tweens ...*Tween) *Tween {
+	return &Tween{children: tweens, parallel: true}
+}
+
+// Then : Returns a sequence of this tween followed by next
+func (t *Tween) Then(next *Tween) *Tween {
+	return Sequence(t, next)
+}
+
+// With : Returns this tween and other played in parallel
+func (t *Tween) With(other *Tween) *Tween {
+	return Parallel(t, other)
+}
+
+// Ease : Sets the easing curve, Linear by default
+func (t *Tween) Ease(easing Easing) *Tween {
+	t.easing = easing
+	return t
+}
+
+// Repeat : Plays the tween count more times after the first, or until
+// stopped if count is Forever
+func (t *Tween) Repeat(count int) *Tween {
+	t.repeat = count
+	return t
+}
+
+// Yoyo : Plays every other repetition backwards
+func (t *Tween) Yoyo() *Tween {
+	t.yoyo = true
+	return t
+}
+
+// OnComplete : Registers a function to be called when the tween first
+// completes, including all of its repetitions
+func (t *Tween) OnComplete(fn func()) *Tween {
+	t.onComplete = append(t.onComplete, fn)
+	return t
+}
+
+// Duration : Returns the duration in milliseconds of one play of the
+// tween, without repetitions
+func (t *Tween) Duration() int {
+	if t.children == nil {
+		return t.duration
+	}
+
+	result := 0
+	for _, child := range t.children {
+		if t.parallel {
+			if child.total() > result {
+				result = child.total()
+			}
+		} else {
+			result = addDuration(result, child.total())
+		}
+	}
+	return result
+}
+
+// total : Returns the duration including repetitions
+func (t *Tween) total() int {
+	if t.repeat == Forever {
+		return maxDuration
+	}
+	total := 0
+	for i := 0; i <= t.repeat; i++ {
+		total = addDuration(total, t.Duration())
+	}
+	return total
+}
+
+func addDuration(a int, b int) int {
+	if a > maxDuration-b {
+		return maxDuration
+	}
+	return a + b
+}
+
+/////////////////////////////////////////////////////////////
+// Playback
+//
+
+// Start : Starts playing the tween from the current tick time.  Start
+// values are captured and applied immediately.  A tween which has
+// completed or been stopped plays again from the beginning, and may be
+// restarted from its own OnComplete callbacks.
+func (t *Tween) Start() *Tween {
+	if t.running {
+		return t
+	}
+
+	t.rewind()
+	t.running = true
+	t.startTick = time.GetTickTime()
+	running = append(running, t)
+
+	if driver == nil || !driver.IsActive() {
+		driver = time.Every(0, update)
+	}
+
+	t.seek(0)
+	return t
+}
+
+// Stop : Stops the tween, leaving the properties at their current values
+func (t *Tween) Stop() {
+	if !t.running {
+		return
+	}
+	t.running = false
+
+	for i, r := range running {
+		if r == t {
+			running = append(running[:i], running[i+1:]...)
+			break
+		}
+	}
+}
+
+// IsRunning : Returns true if the tween has been started and has not
+// completed or been stopped
+func (t *Tween) IsRunning() bool {
+	return t.running
+}
+
+// StopAll : Stops all running tweens
+func StopAll() {
+	for len(running) > 0 {
+		running[0].Stop()
+	}
+}
+
+// update : Advances all running tweens to the current tick time
+func update() {
+	now := time.GetTickTime()
+
+	for _, t := range append([]*Tween{}, running...) {
+		if t.running {
+			t.seek(now - t.startTick)
+		}
+	}
+}
+
+// seek : Applies the state of the tween elapsed milliseconds after it
+// started.  A running tween which has finished is stopped before its
+// OnComplete callbacks are called.
+func (t *Tween) seek(elapsed int) {
+	if !t.begun {
+		t.begun = true
+		if t.begin != nil {
+			t.begin()
+		}
+	}
+
+	base := t.Duration()
+	total := t.total()
+	finished := t.repeat != Forever && elapsed >= total
+
+	local := base
+	cycle := t.repeat
+	if base > 0 && !finished {
+		cycle = elapsed / base
+		local = elapsed % base
+	}
+	if t.yoyo && cycle%2 == 1 {
+		local = base - local
+	}
+
+	t.seekLocal(local)
+
+	if finished && !t.completed {
+		t.completed = true
+		t.Stop()
+		for _, fn := range t.onComplete {
+			fn()
+		}
+	}
+}
+
+// rewind : Marks the tween and its children as not yet begun or
+// completed, so that they begin and complete again when played
+func (t *Tween) rewind() {
+	t.begun = false
+	t.completed = false
+	for _, child := range t.children {
+		child.rewind()
+	}
+}
+
+// seekLocal : Applies the state of a single play of the tween
+func (t *Tween) seekLocal(local int) {
+	if t.children == nil {
+		f := 1.0
+		if t.duration > 0 {
+			f = float64(local) / float64(t.duration)
+		}
+		if t.apply != nil {
+			t.apply(t.easing(f))
+		}
+		return
+	}
+
+	if t.parallel {
+		for _, child := range t.children {
+			child.seek(local)
+		}
+		return
+	}
+
+	current := -1
+	start := 0
+	for i, child := range t.children {
+		if local >= start {
+			current = i
+		}
+		start = addDuration(start, child.total())
+	}
+
+	// Rewind tweens after the current one, last first, so that properties
+	// shared between tweens are left at the current tween's values
+	for i := len(t.children) - 1; i > current; i-- {
+		if t.children[i].begun {
+			t.children[i].seek(0)
+		}
+	}
+
+	start = 0
+	for i := 0; i <= current; i++ {
+		t.children[i].seek(local - start)
+		start = addDuration(start, t.children[i].total())
+	}
+}
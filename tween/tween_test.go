@@ -0,0 +1,192 @@
+package tween
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/leedenison/gologo"
+	"github.com/leedenison/gologo/render"
+	"github.com/leedenison/gologo/time"
+)
+
+const epsilon = 1e-5
+
+var clock = &time.ManualClock{}
+
+// advance : Advances the game clock by ms milliseconds and ticks
+func advance(ms int) {
+	clock.Step(ms)
+	time.Tick()
+}
+
+func reset() {
+	StopAll()
+	time.CancelAll()
+	time.SetClock(clock)
+}
+
+var easingTests = []struct {
+	name   string
+	easing Easing
+}{
+	{"Linear", Linear},
+	{"QuadIn", QuadIn}, {"QuadOut", QuadOut}, {"QuadInOut", QuadInOut},
+	{"CubicIn", CubicIn}, {"CubicOut", CubicOut}, {"CubicInOut", CubicInOut},
+	{"ElasticIn", ElasticIn}, {"ElasticOut", ElasticOut}, {"ElasticInOut", ElasticInOut},
+	{"BounceIn", BounceIn}, {"BounceOut", BounceOut}, {"BounceInOut", BounceInOut},
+	{"BackIn", BackIn}, {"BackOut", BackOut}, {"BackInOut", BackInOut},
+}
+
+// TestEasingEndpoints : Test that every easing starts at 0 and ends at 1
+func TestEasingEndpoints(t *testing.T) {
+	for _, test := range easingTests {
+		t.Run(test.name, func(t *testing.T) {
+			if v := test.easing(0); math.Abs(v) > epsilon {
+				t.Errorf("Start was (%v) should be (0)", v)
+			}
+			if v := test.easing(1); math.Abs(v-1) > epsilon {
+				t.Errorf("End was (%v) should be (1)", v)
+			}
+		})
+	}
+
+	if v := QuadIn(0.5); math.Abs(v-0.25) > epsilon {
+		t.Errorf("QuadIn(0.5) was (%v) should be (0.25)", v)
+	}
+	if v := BackIn(0.2); v >= 0 {
+		t.Errorf("BackIn(0.2) was (%v) should overshoot below 0", v)
+	}
+}
+
+// TestSequence : Test that chained tweens play one after another and
+// call their completion callbacks
+func TestSequence(t *testing.T) {
+	reset()
+
+	o := gologo.CreateObject(mgl32.Vec3{0, 0, 0})
+	o.Scale = 1.0
+	completed := 0
+
+	Position(o, mgl32.Vec3{100, 0, 0}, 100).
+		Then(Scale(o, 3.0, 100)).
+		OnComplete(func() { completed++ }).
+		Start()
+
+	var sequenceTests = []struct {
+		step  int
+		x     float32
+		scale float64
+	}{
+		{50, 50, 1.0},
+		{50, 100, 1.0},
+		{50, 100, 2.0},
+		{100, 100, 3.0},
+	}
+
+	for _, test := range sequenceTests {
+		advance(test.step)
+		if math.Abs(float64(o.Position.X()-test.x)) > epsilon || math.Abs(o.Scale-test.scale) > epsilon {
+			t.Errorf("At %v was (x %v, scale %v) should be (%v, %v)",
+				time.GetTickTime(), o.Position.X(), o.Scale, test.x, test.scale)
+		}
+	}
+
+	if completed != 1 {
+		t.Errorf("Completed (%v) times should be (1)", completed)
+	}
+}
+
+// TestRestart : Test that a tween restarted from its own completion
+// callback begins and completes again
+func TestRestart(t *testing.T) {
+	reset()
+
+	begun := 0
+	completed := 0
+	var tw *Tween
+	tw = New(100, func() { begun++ }, func(f float64) {}).
+		OnComplete(func() {
+			completed++
+			if completed == 1 {
+				tw.Start()
+			}
+		}).
+		Start()
+
+	advance(100)
+	if !tw.IsRunning() || begun != 2 || completed != 1 {
+		t.Errorf("After one play was (running %v, begun %v, completed %v) should be (true, 2, 1)",
+			tw.IsRunning(), begun, completed)
+	}
+
+	advance(100)
+	if tw.IsRunning() || begun != 2 || completed != 2 {
+		t.Errorf("After replay was (running %v, begun %v, completed %v) should be (false, 2, 2)",
+			tw.IsRunning(), begun, completed)
+	}
+}
+
+// TestYoyoRepeat : Test that a yoyo tween plays alternately forwards and
+// backwards for each repetition
+func TestYoyoRepeat(t *testing.T) {
+	reset()
+
+	o := gologo.CreateObject(mgl32.Vec3{})
+	tw := Orientation(o, 1.0, 100).Repeat(2).Yoyo().Start()
+
+	var yoyoTests = []struct {
+		step        int
+		orientation float64
+	}{
+		{25, 0.25},
+		{100, 0.75},
+		{100, 0.25},
+		{100, 1.0},
+	}
+
+	for _, test := range yoyoTests {
+		advance(test.step)
+		if math.Abs(o.Orientation-test.orientation) > epsilon {
+			t.Errorf("At %v orientation was (%v) should be (%v)",
+				time.GetTickTime(), o.Orientation, test.orientation)
+		}
+	}
+
+	if tw.IsRunning() {
+		t.Errorf("Tween should have stopped after its repetitions")
+	}
+}
+
+// TestParallelUniforms : Test that color and alpha tweens run in parallel
+// on the renderer uniforms and stop while the clock is paused
+func TestParallelUniforms(t *testing.T) {
+	reset()
+	defer time.Resume()
+
+	o := gologo.CreateObject(mgl32.Vec3{})
+	o.Renderer = &render.MeshRenderer{
+		Uniforms: map[int]interface{}{
+			render.UniformColor: mgl32.Vec4{1, 0, 0, 1},
+			render.UniformAlpha: float32(1.0),
+		},
+	}
+	uniforms := o.Renderer.(*render.MeshRenderer).Uniforms
+
+	Parallel(
+		Color(o, mgl32.Vec4{0, 0, 1, 1}, 100),
+		Alpha(o, 0.0, 200),
+	).Start()
+
+	advance(50)
+	time.Pause()
+	advance(500)
+
+	color := uniforms[render.UniformColor].(mgl32.Vec4)
+	if !color.ApproxEqualThreshold(mgl32.Vec4{0.5, 0, 0.5, 1}, epsilon) {
+		t.Errorf("Color was (%v) should be (%v)", color, mgl32.Vec4{0.5, 0, 0.5, 1})
+	}
+	if alpha := uniforms[render.UniformAlpha].(float32); math.Abs(float64(alpha)-0.75) > epsilon {
+		t.Errorf("Alpha was (%v) should be (0.75)", alpha)
+	}
+}